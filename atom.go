@@ -0,0 +1,370 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"io"
+	"os"
+	"strings"
+)
+
+// atomFeed represents the feed element of an Atom 1.0 document, as
+// specified in RFC 4287. The atom types are used for parsing and
+// rendering. The elements are tagged with the Atom namespace, so that
+// those of extensions like Media RSS are not mistaken for them.
+type atomFeed struct {
+	XMLName    xml.Name        `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang       string          `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	ID         string          `xml:"http://www.w3.org/2005/Atom id"`
+	Title      atomText        `xml:"http://www.w3.org/2005/Atom title"`
+	Subtitle   atomText        `xml:"http://www.w3.org/2005/Atom subtitle"`
+	Links      []*atomLink     `xml:"http://www.w3.org/2005/Atom link"`
+	Updated    *W3CTime        `xml:"http://www.w3.org/2005/Atom updated"`
+	Authors    []*atomPerson   `xml:"http://www.w3.org/2005/Atom author"`
+	Categories []*atomCategory `xml:"http://www.w3.org/2005/Atom category"`
+	Generator  string          `xml:"http://www.w3.org/2005/Atom generator,omitempty"`
+	Icon       string          `xml:"http://www.w3.org/2005/Atom icon,omitempty"`
+	Logo       string          `xml:"http://www.w3.org/2005/Atom logo,omitempty"`
+	Rights     atomText        `xml:"http://www.w3.org/2005/Atom rights"`
+	Entries    []*atomEntry    `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+// atomEntry represents an entry element of an Atom 1.0 document.
+type atomEntry struct {
+	ID         string          `xml:"http://www.w3.org/2005/Atom id"`
+	Title      atomText        `xml:"http://www.w3.org/2005/Atom title"`
+	Links      []*atomLink     `xml:"http://www.w3.org/2005/Atom link"`
+	Published  *W3CTime        `xml:"http://www.w3.org/2005/Atom published"`
+	Updated    *W3CTime        `xml:"http://www.w3.org/2005/Atom updated"`
+	Authors    []*atomPerson   `xml:"http://www.w3.org/2005/Atom author"`
+	Categories []*atomCategory `xml:"http://www.w3.org/2005/Atom category"`
+	Summary    atomText        `xml:"http://www.w3.org/2005/Atom summary"`
+	Content    atomText        `xml:"http://www.w3.org/2005/Atom content"`
+}
+
+// atomText represents an Atom text construct or content element. Type
+// is "text", "html", "xhtml" or, for content elements, a MIME type.
+// When marshalling, Inner is ignored and nothing is written, if Type
+// and Text are empty.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// atomLink has the fields of AtomLink, so that it can be converted.
+type atomLink struct {
+	XMLName  xml.Name `xml:"link"`
+	Href     string   `xml:"href,attr"`
+	Rel      string   `xml:"rel,attr,omitempty"`
+	Type     string   `xml:"type,attr,omitempty"`
+	HrefLang string   `xml:"hreflang,attr,omitempty"`
+	Title    string   `xml:"title,attr,omitempty"`
+	Length   int      `xml:"length,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name  string `xml:"http://www.w3.org/2005/Atom name"`
+	Email string `xml:"http://www.w3.org/2005/Atom email,omitempty"`
+	URI   string `xml:"http://www.w3.org/2005/Atom uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+// MarshalXML marshals an atomText.
+func (t atomText) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(t.Type) == 0 && len(t.Text) == 0 {
+		return nil
+	}
+	if len(t.Type) > 0 {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: `type`}, Value: t.Type})
+	}
+	return e.EncodeElement(t.Text, start)
+}
+
+// ParseAtom parses an Atom 1.0 document and converts it to an RSS, so
+// that Atom and RSS feeds can be handled alike. An error is returned if
+// the document is not well formed or its root element is not an Atom
+// feed element.
+//
+// The elements of the feed are mapped as follows:
+//   - title, subtitle, rights and generator to the Channel's Title,
+//     Description, Copyright and Generator
+//   - xml:lang to Language
+//   - the alternate link to Link, other links to AtomLinks
+//   - updated to LastBuildDate
+//   - the first author to ManagingEditor
+//   - id to the DublinCore Identifier
+//   - logo or else icon to Image
+//   - category to Categories, using the scheme as Domain
+//
+// The elements of entries are mapped as follows:
+//   - title to the Item's Title
+//   - the alternate link to Link, the first enclosure link to
+//     Enclosure and other links to AtomLinks
+//   - summary to Description and content to Content; if summary is
+//     missing, content is used as Description instead
+//   - the first author to Author, falling back to the feed's author
+//   - id to a GUID, that is no permalink
+//   - published to PubDate, falling back to updated
+//   - category to Categories, using the scheme as Domain
+//
+// Since subtitle is optional in Atom, the Channel's Description may be
+// empty, which is invalid in RSS.
+func ParseAtom(data []byte) (*RSS, error) {
+	return ParseOptions{}.ParseAtom(data)
+}
+
+// ParseAtomFile parses the Atom 1.0 document stored at path. See
+// ParseAtom.
+func ParseAtomFile(path string) (*RSS, error) {
+	return ParseOptions{}.ParseAtomFile(path)
+}
+
+// ParseAtomReader parses an Atom 1.0 document read from r. See
+// ParseAtom.
+func ParseAtomReader(r io.Reader) (*RSS, error) {
+	return ParseOptions{}.ParseAtomReader(r)
+}
+
+// ParseAtom is like the package level ParseAtom, but respects o.
+func (o ParseOptions) ParseAtom(data []byte) (*RSS, error) {
+	return o.ParseAtomReader(bytes.NewReader(data))
+}
+
+// ParseAtomFile is like the package level ParseAtomFile, but respects o.
+func (o ParseOptions) ParseAtomFile(path string) (*RSS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return o.ParseAtomReader(f)
+}
+
+// ParseAtomReader is like the package level ParseAtomReader, but
+// respects o.
+func (o ParseOptions) ParseAtomReader(r io.Reader) (*RSS, error) {
+	decoder := o.newDecoder(r)
+	state := &decodeState{opts: o}
+	decodeStates.Store(decoder, state)
+	defer decodeStates.Delete(decoder)
+	start, err := rootElement(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if start.Name != (xml.Name{Space: AtomNamespace, Local: `feed`}) {
+		return nil, &RootElementError{Name: start.Name}
+	}
+	var feed atomFeed
+	if err = decoder.DecodeElement(&feed, &start); err != nil {
+		return nil, err
+	}
+	rss := NewRSS(feed.channel())
+	if err = o.checkLimits(rss.Channel); err != nil {
+		return nil, err
+	}
+	if len(state.warnings) > 0 {
+		return rss, state.warnings
+	}
+	return rss, nil
+}
+
+func (f *atomFeed) channel() *Channel {
+	ch := &Channel{
+		XMLName:     xml.Name{Local: `channel`},
+		Title:       f.Title.plain(),
+		Description: f.Subtitle.plain(),
+		Language:    f.Lang,
+		Copyright:   f.Rights.plain(),
+		Generator:   strings.TrimSpace(f.Generator),
+	}
+	ch.Link, ch.AtomLinks, _ = convertAtomLinks(f.Links, false)
+	if len(ch.Link) == 0 && isHTTPURL(f.ID) {
+		ch.Link = strings.TrimSpace(f.ID)
+	}
+	if f.Updated != nil {
+		ch.LastBuildDate = &RSSTime{Time: f.Updated.Time, Raw: f.Updated.Raw}
+	}
+	if len(f.Authors) > 0 {
+		ch.ManagingEditor = f.Authors[0].String()
+	}
+	if id := strings.TrimSpace(f.ID); len(id) > 0 {
+		ch.DC = &DublinCore{Identifier: id}
+	}
+	logo := strings.TrimSpace(f.Logo)
+	if len(logo) == 0 {
+		logo = strings.TrimSpace(f.Icon)
+	}
+	if len(logo) > 0 {
+		ch.Image = &Image{
+			XMLName: xml.Name{Local: `image`},
+			URL:     logo,
+			Title:   ch.Title,
+			Link:    ch.Link,
+		}
+	}
+	ch.Categories = convertAtomCategories(f.Categories)
+	for _, entry := range f.Entries {
+		ch.Items = append(ch.Items, entry.item(f.Authors))
+	}
+	return ch
+}
+
+func (e *atomEntry) item(feedAuthors []*atomPerson) *Item {
+	it := &Item{
+		XMLName:     xml.Name{Local: `item`},
+		Title:       e.Title.plain(),
+		Description: e.Summary.html(),
+	}
+	it.Link, it.AtomLinks, it.Enclosure = convertAtomLinks(e.Links, true)
+	if content := e.Content.html(); len(content) > 0 {
+		if len(it.Description) == 0 {
+			it.Description = content
+		} else {
+			it.Content = &Content{
+				XMLName: xml.Name{Local: `content:encoded`},
+				Value:   content,
+			}
+		}
+	}
+	authors := e.Authors
+	if len(authors) == 0 {
+		authors = feedAuthors
+	}
+	if len(authors) > 0 {
+		it.Author = authors[0].String()
+	}
+	if id := strings.TrimSpace(e.ID); len(id) > 0 {
+		it.GUID = &GUID{XMLName: xml.Name{Local: `guid`}, Value: id}
+	}
+	date := e.Published
+	if date == nil {
+		date = e.Updated
+	}
+	if date != nil {
+		it.PubDate = &RSSTime{Time: date.Time, Raw: date.Raw}
+	}
+	it.Categories = convertAtomCategories(e.Categories)
+	return it
+}
+
+// convertAtomLinks returns the href of the first alternate link and
+// converts the others to AtomLinks. If withEnclosure is true, the first
+// enclosure link is returned as an Enclosure instead.
+func convertAtomLinks(links []*atomLink, withEnclosure bool) (string, []*AtomLink, *Enclosure) {
+	var alternate string
+	var atomLinks []*AtomLink
+	var enclosure *Enclosure
+	for _, l := range links {
+		link := AtomLink(*l)
+		link.XMLName = xml.Name{Local: `atom:link`}
+		link.Href = strings.TrimSpace(link.Href)
+		switch {
+		case len(link.Href) == 0:
+		case len(alternate) == 0 && (link.Rel == `` || link.Rel == RelAlternate):
+			alternate = link.Href
+		case withEnclosure && enclosure == nil && link.Rel == `enclosure`:
+			enclosure = &Enclosure{
+				XMLName: xml.Name{Local: `enclosure`},
+				URL:     link.Href,
+				Length:  link.Length,
+				Type:    link.Type,
+			}
+		default:
+			atomLinks = append(atomLinks, &link)
+		}
+	}
+	return alternate, atomLinks, enclosure
+}
+
+func convertAtomCategories(categories []*atomCategory) []*Category {
+	var converted []*Category
+	for _, category := range categories {
+		if term := strings.TrimSpace(category.Term); len(term) > 0 {
+			converted = append(converted, &Category{
+				XMLName: xml.Name{Local: `category`},
+				Value:   term,
+				Domain:  category.Scheme,
+			})
+		}
+	}
+	return converted
+}
+
+// String formats p like the author elements of RSS, i.e.
+// "email (name)", or returns only the name or email, if the other is
+// missing.
+func (p *atomPerson) String() string {
+	name, email := strings.TrimSpace(p.Name), strings.TrimSpace(p.Email)
+	if len(email) > 0 && len(name) > 0 {
+		return email + ` (` + name + `)`
+	} else if len(email) > 0 {
+		return email
+	}
+	return name
+}
+
+// plain returns t as plain text.
+func (t *atomText) plain() string {
+	if t.Type == `xhtml` {
+		return strings.TrimSpace(xmlText(t.Inner))
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+// html returns t as HTML. An empty string is returned for content of
+// MIME types other than text.
+func (t *atomText) html() string {
+	switch {
+	case t.Type == `html`, t.Type == `text/html`:
+		return strings.TrimSpace(t.Text)
+	case t.Type == `xhtml`, t.Type == `application/xhtml+xml`:
+		return xhtmlContent(t.Inner)
+	case t.Type == ``, t.Type == `text`, strings.HasPrefix(t.Type, `text/`):
+		return html.EscapeString(strings.TrimSpace(t.Text))
+	}
+	return ``
+}
+
+// xhtmlContent returns the content of the div element, that wraps
+// XHTML in Atom documents.
+func xhtmlContent(inner string) string {
+	inner = strings.TrimSpace(inner)
+	start := strings.IndexByte(inner, '>')
+	end := strings.LastIndex(inner, `</`)
+	if !strings.HasPrefix(inner, `<`) || start < 0 {
+		return inner
+	} else if end < start {
+		return ``
+	}
+	return strings.TrimSpace(inner[start+1 : end])
+}
+
+// xmlText returns the character data of an XML fragment.
+func xmlText(fragment string) string {
+	decoder := xml.NewDecoder(strings.NewReader(fragment))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return text.String()
+		}
+		if data, ok := token.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+}
+
+// isHTTPURL reports whether s is an absolute HTTP or HTTPS URL.
+func isHTTPURL(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, `http://`) || strings.HasPrefix(s, `https://`)
+}
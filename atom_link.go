@@ -0,0 +1,72 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Common values of AtomLink.Rel.
+const (
+	RelAlternate = `alternate`
+	RelSelf      = `self`
+	RelHub       = `hub`
+	RelFirst     = `first`
+	RelPrevious  = `previous`
+	RelNext      = `next`
+	RelLast      = `last`
+)
+
+// AtomLink represents an atom:link element of a Channel or Item. Href
+// must be present. Most feed validators expect channels to contain a
+// link with Rel "self", that points to the feed itself.
+type AtomLink struct {
+	XMLName  xml.Name `xml:"atom:link"`
+	Href     string   `xml:"href,attr"`
+	Rel      string   `xml:"rel,attr,omitempty"`
+	Type     string   `xml:"type,attr,omitempty"`
+	HrefLang string   `xml:"hreflang,attr,omitempty"`
+	Title    string   `xml:"title,attr,omitempty"`
+	Length   int      `xml:"length,attr,omitempty"`
+}
+
+// NewAtomLink creates a new AtomLink element. rel may be empty, which
+// is equivalent to "alternate".
+func NewAtomLink(href, rel string) (*AtomLink, error) {
+	if len(href) == 0 {
+		return nil, fmt.Errorf(`empty href passed to NewAtomLink()`)
+	}
+	return &AtomLink{
+		XMLName: xml.Name{Local: `atom:link`},
+		Href:    href,
+		Rel:     rel,
+	}, nil
+}
+
+// NewSelfLink creates a new AtomLink element, that points to an RSS
+// feed at href.
+func NewSelfLink(href string) (*AtomLink, error) {
+	link, err := NewAtomLink(href, RelSelf)
+	if err != nil {
+		return nil, err
+	}
+	link.Type = `application/rss+xml`
+	return link, nil
+}
+
+// findAtomLink returns the first link with the given rel.
+func findAtomLink(links []*AtomLink, rel string) *AtomLink {
+	for _, link := range links {
+		if link.Rel == rel || len(link.Rel) == 0 && rel == RelAlternate {
+			return link
+		}
+	}
+	return nil
+}
+
+func (l *AtomLink) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@href`, l.Href)
+	if l.Length < 0 {
+		errs.add(path+`@length`, `must not be negative`)
+	}
+	return
+}
@@ -0,0 +1,431 @@
+package rss2
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var reRSSPerson = regexp.MustCompile(`^(\S+@\S+)\s+\((.+)\)$`)
+
+// UnrepresentableError is reported as a warning, if a field of a
+// Channel or Item cannot be represented in the format it is rendered
+// in. Path has the form of ValidationError.Path.
+type UnrepresentableError struct {
+	Path   string
+	Format string
+}
+
+func (e *UnrepresentableError) Error() string {
+	return fmt.Sprintf(`%s cannot be represented in %s`, e.Path, e.Format)
+}
+
+// RenderAtom writes ch as an Atom 1.0 document, including the XML
+// declaration, to w. The fields of the channel are mapped as follows:
+//   - the DublinCore Identifier or else Link to id; see below
+//   - Title, Description, Copyright and Generator to title, subtitle,
+//     rights and generator
+//   - Language to xml:lang
+//   - Link to the alternate link, AtomLinks to further links
+//   - LastBuildDate or else PubDate to updated; if both are missing,
+//     the newest date of the items or else the Unix epoch is used, so
+//     that rendering the same channel always yields the same document
+//   - ManagingEditor or else the DublinCore Creators to author; if
+//     both are missing and not all items have an author, WebMaster or
+//     else Title is used, since Atom requires an author
+//   - the URL of Image to logo
+//   - Categories to category, using Domain as scheme
+//
+// The fields of items are mapped as follows:
+//   - GUID or else Link to id; see below
+//   - Title to title, Description to summary and Content to content,
+//     all of which are rendered as HTML, except for the title; items
+//     without Content and Link get Description as content, since Atom
+//     requires content for entries without an alternate link
+//   - Link to the alternate link, Enclosure to a link with rel
+//     "enclosure", AtomLinks to further links
+//   - PubDate to published and updated; if it is missing, the
+//     DublinCore Date or else the feed's updated is used for updated
+//   - Author or else the DublinCore Creators to author
+//   - Categories to category, using Domain as scheme
+//
+// Atom requires ids to be IRIs. Values, that are no absolute IRIs, and
+// missing ids of items are replaced by name based UUID URNs, so that
+// rendering the same channel always yields the same ids.
+//
+// Fields without an equivalent in Atom, e.g. Cloud or the iTunes
+// extension, are left out and reported as Warnings of
+// *UnrepresentableError. The document is complete in that case.
+func (ch *Channel) RenderAtom(w io.Writer, opts ...RenderOption) error {
+	var warnings Warnings
+	feed := ch.atomFeed(func(path string) {
+		warnings = append(warnings, &UnrepresentableError{Path: path, Format: `Atom`})
+	})
+	if err := render(w, feed, opts); err != nil {
+		return err
+	}
+	if len(warnings) > 0 {
+		return warnings
+	}
+	return nil
+}
+
+// AtomBytes returns the document written by RenderAtom. Warnings are
+// returned together with the document.
+func (ch *Channel) AtomBytes(opts ...RenderOption) ([]byte, error) {
+	var buf bytes.Buffer
+	err := ch.RenderAtom(&buf, opts...)
+	var warnings Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		return nil, err
+	}
+	return buf.Bytes(), err
+}
+
+// The rendered* types have the fields of atomFeed, atomEntry and
+// atomPerson without the Atom namespace in their tags. They are
+// marshalled instead, so that the elements inherit the default
+// namespace of the feed element instead of declaring it each.
+type renderedAtomFeed struct {
+	XMLName    xml.Name        `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang       string          `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	ID         string          `xml:"id"`
+	Title      atomText        `xml:"title"`
+	Subtitle   atomText        `xml:"subtitle"`
+	Links      []*atomLink     `xml:"link"`
+	Updated    *W3CTime        `xml:"updated"`
+	Authors    []*atomPerson   `xml:"author"`
+	Categories []*atomCategory `xml:"category"`
+	Generator  string          `xml:"generator,omitempty"`
+	Icon       string          `xml:"icon,omitempty"`
+	Logo       string          `xml:"logo,omitempty"`
+	Rights     atomText        `xml:"rights"`
+	Entries    []*atomEntry    `xml:"entry"`
+}
+
+type renderedAtomEntry struct {
+	ID         string          `xml:"id"`
+	Title      atomText        `xml:"title"`
+	Links      []*atomLink     `xml:"link"`
+	Published  *W3CTime        `xml:"published"`
+	Updated    *W3CTime        `xml:"updated"`
+	Authors    []*atomPerson   `xml:"author"`
+	Categories []*atomCategory `xml:"category"`
+	Summary    atomText        `xml:"summary"`
+	Content    atomText        `xml:"content"`
+}
+
+type renderedAtomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+	URI   string `xml:"uri,omitempty"`
+}
+
+// MarshalXML marshals an atomFeed.
+func (f atomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(renderedAtomFeed(f))
+}
+
+// MarshalXML marshals an atomEntry.
+func (entry atomEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(renderedAtomEntry(entry), start)
+}
+
+// MarshalXML marshals an atomPerson.
+func (p atomPerson) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(renderedAtomPerson(p), start)
+}
+
+func (ch *Channel) atomFeed(unrepresentable func(path string)) *atomFeed {
+	const path = `channel`
+	feed := &atomFeed{
+		Lang:      ch.Language,
+		Title:     atomText{Type: `text`, Text: ch.Title},
+		Rights:    atomText{Text: ch.Copyright},
+		Generator: ch.Generator,
+	}
+	if len(ch.Description) > 0 {
+		feed.Subtitle = atomText{Type: `html`, Text: ch.Description}
+	}
+	feed.ID = ch.Link
+	if ch.DC != nil && len(ch.DC.Identifier) > 0 {
+		feed.ID = ch.DC.Identifier
+	}
+	if !isAbsoluteIRI(feed.ID) {
+		feed.ID = uuidURN(feed.ID)
+	}
+	feed.Links = atomLinks(ch.Link, ch.AtomLinks)
+	if ch.LastBuildDate != nil {
+		feed.Updated = atomTime(ch.LastBuildDate, path+`/lastBuildDate`, unrepresentable)
+		if ch.PubDate != nil {
+			unrepresentable(path + `/pubDate`)
+		}
+	} else if ch.PubDate != nil {
+		feed.Updated = atomTime(ch.PubDate, path+`/pubDate`, unrepresentable)
+	}
+	var dc DublinCore
+	if ch.DC != nil {
+		dc = *ch.DC
+	}
+	if len(ch.ManagingEditor) > 0 {
+		feed.Authors = []*atomPerson{parseRSSPerson(ch.ManagingEditor)}
+	} else if len(dc.Creators) > 0 {
+		feed.Authors, dc.Creators = atomCreators(dc.Creators), nil
+	}
+	webMaster := ch.WebMaster
+	if len(feed.Authors) == 0 && !ch.itemsHaveAuthors() {
+		// Atom requires an author for the feed, unless all entries
+		// have one.
+		if len(webMaster) > 0 {
+			feed.Authors, webMaster = []*atomPerson{parseRSSPerson(webMaster)}, ``
+		} else {
+			feed.Authors = []*atomPerson{{Name: ch.Title}}
+		}
+	}
+	if ch.Image != nil {
+		feed.Logo = ch.Image.URL
+	}
+	feed.Categories = atomCategories(ch.Categories)
+
+	for _, field := range []struct {
+		element string
+		present bool
+	}{
+		{`webMaster`, len(webMaster) > 0},
+		{`docs`, len(ch.Docs) > 0},
+		{`cloud`, ch.Cloud != nil},
+		{`ttl`, ch.TTL != 0},
+		{`rating`, len(ch.Rating) > 0},
+		{`textInput`, ch.TextInput != nil},
+		{`skipHours`, ch.SkipHours != nil},
+		{`skipDays`, ch.SkipDays != nil},
+		{`itunes:*`, ch.ITunes != nil},
+		{`podcast:*`, ch.Podcast != nil},
+	} {
+		if field.present {
+			unrepresentable(path + `/` + field.element)
+		}
+	}
+	dc.Identifier = ``
+	dc.unrepresentable(path, unrepresentable)
+	unrepresentableExtensions(path, ch.Extensions, ch.Attrs, unrepresentable)
+
+	for i, item := range ch.Items {
+		feed.Entries = append(feed.Entries,
+			item.atomEntry(feed.ID, indexPath(path, `item`, i), unrepresentable))
+	}
+	if feed.Updated == nil {
+		for _, entry := range feed.Entries {
+			if entry.Updated != nil &&
+				(feed.Updated == nil || entry.Updated.Time.After(feed.Updated.Time)) {
+				feed.Updated = entry.Updated
+			}
+		}
+	}
+	if feed.Updated == nil {
+		feed.Updated = &W3CTime{Time: time.Unix(0, 0).UTC()}
+	}
+	for _, entry := range feed.Entries {
+		if entry.Updated == nil {
+			entry.Updated = feed.Updated
+		}
+	}
+	return feed
+}
+
+func (it *Item) atomEntry(feedID, path string, unrepresentable func(path string)) *atomEntry {
+	entry := &atomEntry{
+		Title:   atomText{Type: `text`, Text: it.Title},
+		Summary: atomText{Text: it.Description},
+	}
+	if len(it.Description) > 0 {
+		entry.Summary.Type = `html`
+	}
+	if it.Content != nil {
+		entry.Content = atomText{Type: `html`, Text: it.Content.Value}
+	} else if len(it.Link) == 0 && findAtomLink(it.AtomLinks, RelAlternate) == nil {
+		// Atom requires entries without an alternate link to have
+		// content.
+		entry.Summary, entry.Content = atomText{}, atomText{Type: `text`}
+		if len(it.Description) > 0 {
+			entry.Content = atomText{Type: `html`, Text: it.Description}
+		}
+	}
+	switch {
+	case it.GUID != nil && isAbsoluteIRI(it.GUID.Value):
+		entry.ID = it.GUID.Value
+	case it.GUID != nil:
+		entry.ID = uuidURN(feedID + `#` + it.GUID.Value)
+	case isAbsoluteIRI(it.Link):
+		entry.ID = it.Link
+	default:
+		entry.ID = uuidURN(feedID + `#` + it.Link + "\n" + it.Title + "\n" + it.Description)
+	}
+	entry.Links = atomLinks(it.Link, it.AtomLinks)
+	if it.Enclosure != nil {
+		entry.Links = append(entry.Links, &atomLink{
+			Href:   it.Enclosure.URL,
+			Rel:    `enclosure`,
+			Type:   it.Enclosure.Type,
+			Length: it.Enclosure.Length,
+		})
+	}
+	if it.PubDate != nil {
+		entry.Published = atomTime(it.PubDate, path+`/pubDate`, unrepresentable)
+		entry.Updated = entry.Published
+	}
+	var dc DublinCore
+	if it.DC != nil {
+		dc = *it.DC
+	}
+	if entry.Updated == nil && dc.Date != nil && !dc.Date.Time.IsZero() {
+		entry.Updated, dc.Date = dc.Date, nil
+	}
+	if len(it.Author) > 0 {
+		entry.Authors = []*atomPerson{parseRSSPerson(it.Author)}
+	} else if len(dc.Creators) > 0 {
+		entry.Authors, dc.Creators = atomCreators(dc.Creators), nil
+	}
+	entry.Categories = atomCategories(it.Categories)
+
+	for _, field := range []struct {
+		element string
+		present bool
+	}{
+		{`comments`, len(it.Comments) > 0},
+		{`source`, it.Source != nil},
+		{`itunes:*`, it.ITunes != nil},
+		{`podcast:*`, it.Podcast != nil},
+		{`media:*`, it.Media != nil},
+	} {
+		if field.present {
+			unrepresentable(path + `/` + field.element)
+		}
+	}
+	dc.unrepresentable(path, unrepresentable)
+	unrepresentableExtensions(path, it.Extensions, it.Attrs, unrepresentable)
+	return entry
+}
+
+// itemsHaveAuthors reports whether all items of ch are rendered with an
+// author.
+func (ch *Channel) itemsHaveAuthors() bool {
+	for _, it := range ch.Items {
+		if len(it.Author) == 0 && (it.DC == nil || len(it.DC.Creators) == 0) {
+			return false
+		}
+	}
+	return len(ch.Items) > 0
+}
+
+// unrepresentable reports the fields of dc, that are set.
+func (dc *DublinCore) unrepresentable(path string, unrepresentable func(path string)) {
+	for _, field := range []struct {
+		element string
+		present bool
+	}{
+		{`dc:creator`, len(dc.Creators) > 0},
+		{`dc:date`, dc.Date != nil},
+		{`dc:subject`, len(dc.Subjects) > 0},
+		{`dc:rights`, len(dc.Rights) > 0},
+		{`dc:language`, len(dc.Language) > 0},
+		{`dc:publisher`, len(dc.Publisher) > 0},
+		{`dc:identifier`, len(dc.Identifier) > 0},
+	} {
+		if field.present {
+			unrepresentable(path + `/` + field.element)
+		}
+	}
+}
+
+func unrepresentableExtensions(path string, extensions []*Element, attrs []xml.Attr,
+	unrepresentable func(path string)) {
+	for _, el := range extensions {
+		unrepresentable(path + `/` + el.XMLName.Local)
+	}
+	for _, attr := range attrs {
+		unrepresentable(path + `@` + attr.Name.Local)
+	}
+}
+
+// atomLinks returns the alternate link to href, if it is not empty,
+// followed by links.
+func atomLinks(href string, links []*AtomLink) []*atomLink {
+	var converted []*atomLink
+	if len(href) > 0 {
+		converted = append(converted, &atomLink{Href: href, Rel: RelAlternate})
+	}
+	for _, link := range links {
+		l := atomLink(*link)
+		l.XMLName = xml.Name{}
+		converted = append(converted, &l)
+	}
+	return converted
+}
+
+func atomCategories(categories []*Category) []*atomCategory {
+	var converted []*atomCategory
+	for _, category := range categories {
+		converted = append(converted, &atomCategory{Term: category.Value, Scheme: category.Domain})
+	}
+	return converted
+}
+
+func atomCreators(creators []string) []*atomPerson {
+	var persons []*atomPerson
+	for _, creator := range creators {
+		persons = append(persons, &atomPerson{Name: creator})
+	}
+	return persons
+}
+
+// atomTime converts t. Dates, that could not be parsed, are reported
+// and nil is returned for them.
+func atomTime(t *RSSTime, path string, unrepresentable func(path string)) *W3CTime {
+	if t.Time.IsZero() {
+		unrepresentable(path)
+		return nil
+	}
+	return &W3CTime{Time: t.Time}
+}
+
+// parseRSSPerson parses the value of an RSS author or managingEditor
+// element, which is usually of the form "email (name)".
+func parseRSSPerson(s string) *atomPerson {
+	s = strings.TrimSpace(s)
+	if match := reRSSPerson.FindStringSubmatch(s); match != nil {
+		return &atomPerson{Name: match[2], Email: match[1]}
+	} else if strings.Contains(s, `@`) && !strings.ContainsAny(s, " \t") {
+		return &atomPerson{Name: s, Email: s}
+	}
+	return &atomPerson{Name: s}
+}
+
+// isAbsoluteIRI reports whether s is an absolute IRI, like Atom requires
+// for ids.
+func isAbsoluteIRI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && len(u.Scheme) > 0 && !strings.ContainsAny(s, " \t\n")
+}
+
+// uuidURN returns the name based UUID (version 5) of name in the URL
+// namespace of RFC 4122, as URN.
+func uuidURN(name string) string {
+	namespaceURL := []byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	hash := sha1.New()
+	hash.Write(namespaceURL)
+	hash.Write([]byte(name))
+	uuid := hash.Sum(nil)[:16]
+	uuid[6] = uuid[6]&0x0f | 0x50
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return fmt.Sprintf(`urn:uuid:%x-%x-%x-%x-%x`,
+		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
@@ -0,0 +1,297 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAtom(t *testing.T) {
+	input := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <title type="text">Example Feed</title>
+  <subtitle type="html">A &lt;em&gt;subtitle&lt;/em&gt;</subtitle>
+  <link href="https://example.org/"/>
+  <link rel="self" type="application/atom+xml" href="https://example.org/feed.atom"/>
+  <updated>2003-12-13T18:30:02Z</updated>
+  <author><name>John Doe</name><email>john@example.org</email></author>
+  <id>urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6</id>
+  <rights>© 2003 John Doe</rights>
+  <generator uri="https://example.org/gen">Example Generator</generator>
+  <logo>https://example.org/logo.png</logo>
+  <category term="tech" scheme="https://example.org/categories"/>
+  <entry>
+    <title>Atom-Powered Robots Run Amok</title>
+    <link href="https://example.org/2003/12/13/atom03"/>
+    <link rel="enclosure" type="audio/mpeg" length="1337" href="https://example.org/audio.mp3"/>
+    <link rel="related" href="https://example.org/related"/>
+    <id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+    <published>2003-12-13T08:29:29-04:00</published>
+    <updated>2003-12-13T18:30:02Z</updated>
+    <summary>Some text &amp; more.</summary>
+    <content type="xhtml">
+      <div xmlns="http://www.w3.org/1999/xhtml"><p>Full <b>content</b>.</p></div>
+    </content>
+    <category term="robots"/>
+  </entry>
+  <entry>
+    <title type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">Second <i>entry</i></div></title>
+    <author><name>Jane Doe</name></author>
+    <updated>2003-12-14T10:00:00Z</updated>
+    <media:title>MT</media:title>
+    <content type="html">&lt;p&gt;Only content&lt;/p&gt;</content>
+    <media:content url="https://example.org/video.mp4"/>
+    <media:group><media:title>Group</media:title></media:group>
+    <media:thumbnail url="https://example.org/thumb.jpg"/>
+  </entry>
+</feed>`
+	expected := &RSS{
+		XMLName: xml.Name{Local: `rss`},
+		Version: `2.0`,
+		Channel: &Channel{
+			XMLName:        xml.Name{Local: `channel`},
+			Title:          `Example Feed`,
+			Link:           `https://example.org/`,
+			Description:    `A <em>subtitle</em>`,
+			Copyright:      `© 2003 John Doe`,
+			ManagingEditor: `john@example.org (John Doe)`,
+			LastBuildDate:  &RSSTime{Time: time.Date(2003, 12, 13, 18, 30, 2, 0, time.UTC)},
+			Categories: []*Category{{
+				XMLName: xml.Name{Local: `category`},
+				Value:   `tech`,
+				Domain:  `https://example.org/categories`,
+			}},
+			Generator: `Example Generator`,
+			Image: &Image{
+				XMLName: xml.Name{Local: `image`},
+				URL:     `https://example.org/logo.png`,
+				Title:   `Example Feed`,
+				Link:    `https://example.org/`,
+			},
+			AtomLinks: []*AtomLink{{
+				XMLName: xml.Name{Local: `atom:link`},
+				Href:    `https://example.org/feed.atom`,
+				Rel:     `self`,
+				Type:    `application/atom+xml`,
+			}},
+			DC: &DublinCore{Identifier: `urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6`},
+			Items: []*Item{
+				{
+					XMLName:     xml.Name{Local: `item`},
+					Title:       `Atom-Powered Robots Run Amok`,
+					Link:        `https://example.org/2003/12/13/atom03`,
+					Description: `Some text &amp; more.`,
+					Author:      `john@example.org (John Doe)`,
+					Categories:  []*Category{{XMLName: xml.Name{Local: `category`}, Value: `robots`}},
+					Enclosure: &Enclosure{
+						XMLName: xml.Name{Local: `enclosure`},
+						URL:     `https://example.org/audio.mp3`,
+						Length:  1337,
+						Type:    `audio/mpeg`,
+					},
+					GUID: &GUID{
+						XMLName: xml.Name{Local: `guid`},
+						Value:   `urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a`,
+					},
+					PubDate: &RSSTime{Time: time.Date(2003, 12, 13, 8, 29, 29, 0, time.FixedZone(``, -4*60*60))},
+					Content: &Content{
+						XMLName: xml.Name{Local: `content:encoded`},
+						Value:   `<p>Full <b>content</b>.</p>`,
+					},
+					AtomLinks: []*AtomLink{{
+						XMLName: xml.Name{Local: `atom:link`},
+						Href:    `https://example.org/related`,
+						Rel:     `related`,
+					}},
+				},
+				{
+					XMLName:     xml.Name{Local: `item`},
+					Title:       `Second entry`,
+					Description: `<p>Only content</p>`,
+					Author:      `Jane Doe`,
+					PubDate:     &RSSTime{Time: time.Date(2003, 12, 14, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+	rss, err := ParseAtom([]byte(input))
+	if err != nil {
+		t.Fatal("Failed to parse:", err)
+	}
+	if diff := cmp.Diff(expected, rss); diff != "" {
+		t.Errorf("Atom parsing mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseAtomErrors(t *testing.T) {
+	var rootErr *RootElementError
+	_, err := ParseAtom([]byte(`<rss version="2.0"><channel></channel></rss>`))
+	if !errors.As(err, &rootErr) {
+		t.Errorf("Expected RootElementError for rss element, got '%v'", err)
+	}
+	_, err = ParseAtom([]byte(`<feed><title>No namespace</title></feed>`))
+	if !errors.As(err, &rootErr) {
+		t.Errorf("Expected RootElementError for feed without namespace, got '%v'", err)
+	}
+
+	input := `<feed xmlns="http://www.w3.org/2005/Atom"><title>T</title>
+<entry><title>E</title><updated>yesterday</updated></entry></feed>`
+	if _, err = ParseAtom([]byte(input)); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+	rss, err := ParseOptions{KeepInvalidDates: true}.ParseAtom([]byte(input))
+	var warnings Warnings
+	if !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Errorf("Expected one warning, got '%v'", err)
+	} else if raw := rss.Channel.Items[0].PubDate.Raw; raw != `yesterday` {
+		t.Errorf("Expected raw date 'yesterday', got '%s'", raw)
+	}
+}
+
+func TestRenderAtom(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel <b>description</b>`)
+	channel.Language = `en`
+	channel.ManagingEditor = `jane@foo.com (Jane Doe)`
+	channel.LastBuildDate = &RSSTime{Time: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)}
+	channel.TTL = 60
+	channel.AtomLinks = []*AtomLink{{Href: `https://foo.com/feed.xml`, Rel: RelSelf}}
+	item1, _ := NewItem(`Item 1`, `Item <i>description</i>`)
+	item1.Link = `https://foo.com/1`
+	item1.GUID, _ = NewGUID(`https://foo.com/1`)
+	item1.PubDate = &RSSTime{Time: time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)}
+	item1.Enclosure, _ = NewEnclosure(`https://foo.com/1.mp3`, 42, `audio/mpeg`)
+	item1.Content, _ = NewContent(`<p>Full content</p>`)
+	item1.Comments = `https://foo.com/1#comments`
+	item1.Categories = []*Category{{Value: `news`, Domain: `https://foo.com/categories`}}
+	item2, _ := NewItem(`Item 2`, ``)
+	item2.GUID, _ = NewGUID(`42`)
+	item2.Author = `john@foo.com`
+	item3, _ := NewItem(``, `Item without title and id`)
+	channel.Items = []*Item{item1, item2, item3}
+
+	out, err := channel.AtomBytes(WithIndent(``, `  `))
+	expectedWarnings := Warnings{
+		&UnrepresentableError{Path: `channel/ttl`, Format: `Atom`},
+		&UnrepresentableError{Path: `channel/item[0]/comments`, Format: `Atom`},
+	}
+	if diff := cmp.Diff(expectedWarnings, err); diff != "" {
+		t.Errorf("Warnings mismatch (-want +got):\n%s", diff)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en">
+  <id>https://foo.com/</id>
+  <title type="text">Channel title</title>
+  <subtitle type="html">Channel &lt;b&gt;description&lt;/b&gt;</subtitle>
+  <link href="https://foo.com/" rel="alternate"></link>
+  <link href="https://foo.com/feed.xml" rel="self"></link>
+  <updated>2023-04-01T12:00:00Z</updated>
+  <author>
+    <name>Jane Doe</name>
+    <email>jane@foo.com</email>
+  </author>
+  <entry>
+    <id>https://foo.com/1</id>
+    <title type="text">Item 1</title>
+    <link href="https://foo.com/1" rel="alternate"></link>
+    <link href="https://foo.com/1.mp3" rel="enclosure" type="audio/mpeg" length="42"></link>
+    <published>2023-04-01T10:00:00Z</published>
+    <updated>2023-04-01T10:00:00Z</updated>
+    <category term="news" scheme="https://foo.com/categories"></category>
+    <summary type="html">Item &lt;i&gt;description&lt;/i&gt;</summary>
+    <content type="html">&lt;p&gt;Full content&lt;/p&gt;</content>
+  </entry>
+  <entry>
+    <id>urn:uuid:6183617b-69d3-58d7-9c6e-dd05fd11b0d8</id>
+    <title type="text">Item 2</title>
+    <updated>2023-04-01T12:00:00Z</updated>
+    <author>
+      <name>john@foo.com</name>
+      <email>john@foo.com</email>
+    </author>
+    <content type="text"></content>
+  </entry>
+  <entry>
+    <id>urn:uuid:44da0eae-da77-5126-b394-149c243214d2</id>
+    <title type="text"></title>
+    <updated>2023-04-01T12:00:00Z</updated>
+    <content type="html">Item without title and id</content>
+  </entry>
+</feed>
+`
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Errorf("Atom rendering mismatch (-want +got):\n%s", diff)
+	}
+
+	rss, err := ParseAtom(out)
+	if err != nil {
+		t.Fatal("Failed to parse rendered Atom:", err)
+	}
+	if diff := cmp.Diff(item1.Content, rss.Channel.Items[0].Content); diff != "" {
+		t.Errorf("Content mismatch after round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderAtomRequiredElements(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	channel.LastBuildDate = &RSSTime{Time: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)}
+	item, _ := NewItem(`t`, `d`)
+	item.GUID, _ = NewGUID(`urn:foo:1`)
+	channel.Items = []*Item{item}
+	out, err := channel.AtomBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><id>https://foo.com/</id><title type="text">Channel title</title>` +
+		`<subtitle type="html">Channel description</subtitle><link href="https://foo.com/" rel="alternate"></link>` +
+		`<updated>2023-04-01T12:00:00Z</updated><author><name>Channel title</name></author>` +
+		`<entry><id>urn:foo:1</id><title type="text">t</title><updated>2023-04-01T12:00:00Z</updated>` +
+		`<content type="html">d</content></entry></feed>
+`
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Errorf("Atom rendering mismatch (-want +got):\n%s", diff)
+	}
+
+	// WebMaster is used as author and thus not reported.
+	channel.WebMaster = `web@foo.com (Web Master)`
+	if out, err = channel.AtomBytes(); err != nil {
+		t.Fatal(err)
+	}
+	if author := `<author><name>Web Master</name><email>web@foo.com</email></author>`; !strings.Contains(string(out), author) {
+		t.Errorf("Expected author '%s' in '%s'", author, out)
+	}
+
+	// No author is needed for the feed, if all entries have one.
+	item.Author = `jane@foo.com`
+	channel.WebMaster = ``
+	if out, err = channel.AtomBytes(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(out), `<author>`) != 1 {
+		t.Errorf("Expected only the entry's author in '%s'", out)
+	}
+
+	// Without any dates, the Unix epoch is used, so that the output is
+	// the same every time.
+	channel.LastBuildDate = nil
+	if out, err = channel.AtomBytes(); err != nil {
+		t.Fatal(err)
+	}
+	if updated := `<updated>1970-01-01T00:00:00Z</updated>`; strings.Count(string(out), updated) != 2 {
+		t.Errorf("Expected updated '%s' for feed and entry in '%s'", updated, out)
+	}
+	if again, _ := channel.AtomBytes(); string(again) != string(out) {
+		t.Errorf("Rendering again yielded '%s'. Expected '%s'", again, out)
+	}
+}
+
+func TestUUIDURN(t *testing.T) {
+	// As computed by Python's uuid.uuid5(uuid.NAMESPACE_URL, ...).
+	if urn := uuidURN(`http://www.example.com/`); urn != `urn:uuid:fcde3c85-2270-590f-9e7c-ee003d65e0e2` {
+		t.Errorf("Unexpected UUID '%s'", urn)
+	}
+}
@@ -23,3 +23,8 @@ func NewCategory(value string) (*Category, error) {
 		Value:   value,
 	}, nil
 }
+
+func (c *Category) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path, c.Value)
+	return
+}
@@ -6,31 +6,59 @@ import (
 )
 
 // Channel represents an rss channel element. Title, Link and
-// Description are required.
+// Description are required. AtomLinks, DC, ITunes and Podcast are
+// extensions. The elements of DC, ITunes and Podcast are rendered as
+// children of the channel. Elements and attributes
+// unknown to this package are kept in Extensions and Attrs.
 type Channel struct {
-	XMLName        xml.Name    `xml:"channel"`
-	Title          string      `xml:"title"`
-	Link           string      `xml:"link"`
-	Description    string      `xml:"description"`
-	Language       string      `xml:"language,omitempty"`
-	Copyright      string      `xml:"copyright,omitempty"`
-	ManagingEditor string      `xml:"managingEditor,omitempty"`
-	WebMaster      string      `xml:"webMaster,omitempty"`
-	PubDate        *RSSTime    `xml:"pubDate,omitempty"`
-	LastBuildDate  *RSSTime    `xml:"lastBuildDate,omitempty"`
-	Categories     []*Category `xml:"category,omitempty"`
-	Generator      string      `xml:"generator,omitempty"`
-	Docs           string      `xml:"docs,omitempty"`
-	Cloud          *Cloud      `xml:"cloud,omitempty"`
-	TTL            int         `xml:"ttl,omitempty"`
-	Image          *Image      `xml:"image,omitempty"`
-	Rating         string      `xml:"rating,omitempty"`
-	TextInput      *TextInput  `xml:"textInput,omitempty"`
-	SkipHours      *SkipHours  `xml:"skipHours,omitempty"`
-	SkipDays       *SkipDays   `xml:"skipDays,omitempty"`
-	Items          []*Item     `xml:"item,omitempty"`
+	XMLName        xml.Name        `xml:"channel"`
+	Title          string          `xml:"title"`
+	Link           string          `xml:"link"`
+	Description    string          `xml:"description"`
+	Language       string          `xml:"language,omitempty"`
+	Copyright      string          `xml:"copyright,omitempty"`
+	ManagingEditor string          `xml:"managingEditor,omitempty"`
+	WebMaster      string          `xml:"webMaster,omitempty"`
+	PubDate        *RSSTime        `xml:"pubDate,omitempty"`
+	LastBuildDate  *RSSTime        `xml:"lastBuildDate,omitempty"`
+	Categories     []*Category     `xml:"category,omitempty"`
+	Generator      string          `xml:"generator,omitempty"`
+	Docs           string          `xml:"docs,omitempty"`
+	Cloud          *Cloud          `xml:"cloud,omitempty"`
+	TTL            int             `xml:"ttl,omitempty"`
+	Image          *Image          `xml:"image,omitempty"`
+	Rating         string          `xml:"rating,omitempty"`
+	TextInput      *TextInput      `xml:"textInput,omitempty"`
+	SkipHours      *SkipHours      `xml:"skipHours,omitempty"`
+	SkipDays       *SkipDays       `xml:"skipDays,omitempty"`
+	AtomLinks      []*AtomLink     `xml:"atom:link,omitempty"`
+	DC             *DublinCore     `xml:"-"`
+	ITunes         *ITunesChannel  `xml:"-"`
+	Podcast        *PodcastChannel `xml:"-"`
+	Extensions     []*Element      `xml:",any"`
+	Attrs          []xml.Attr      `xml:",any,attr"`
+
+	// Items are kept last, so that they follow all other elements of
+	// the channel when rendered.
+	Items []*Item `xml:"item,omitempty"`
+}
+
+// channelElements is marshalled and unmarshalled in place of a Channel,
+// so that the fields of its extensions become elements of the channel.
+// Extensions and Items shadow those of the channel to keep them last.
+type channelElements struct {
+	XMLName xml.Name `xml:"channel"`
+	plainChannel
+	*DublinCore
+	*ITunesChannel
+	*PodcastChannel
+	Extensions []*Element `xml:",any"`
+	Items      []*Item    `xml:"item,omitempty"`
 }
 
+// plainChannel has the fields, but not the methods of Channel.
+type plainChannel Channel
+
 // NewChannel creates a new Channel.
 func NewChannel(title, link, description string) (*Channel, error) {
 	if len(title) == 0 || len(link) == 0 || len(description) == 0 {
@@ -43,3 +71,118 @@ func NewChannel(title, link, description string) (*Channel, error) {
 		Description: description,
 	}, nil
 }
+
+// MarshalXML marshals a channel element.
+func (ch Channel) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(ch.elements())
+}
+
+// UnmarshalXML unmarshals a channel element. Fields that are already
+// set are kept, unless the element overrides them.
+func (ch *Channel) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	elements := ch.elements()
+	err := decoder.DecodeElement(elements, &start)
+	*ch = Channel(elements.plainChannel)
+	ch.XMLName, ch.DC = elements.XMLName, elements.DublinCore
+	ch.ITunes, ch.Podcast = elements.ITunesChannel, elements.PodcastChannel
+	ch.Extensions, ch.Items = elements.Extensions, elements.Items
+	return err
+}
+
+func (ch *Channel) elements() *channelElements {
+	return &channelElements{
+		plainChannel:   plainChannel(*ch),
+		DublinCore:     ch.DC,
+		ITunesChannel:  ch.ITunes,
+		PodcastChannel: ch.Podcast,
+		Extensions:     ch.Extensions,
+		Items:          ch.Items,
+	}
+}
+
+func (ch *Channel) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`/title`, ch.Title)
+	errs.requireNonEmpty(path+`/link`, ch.Link)
+	errs.requireNonEmpty(path+`/description`, ch.Description)
+	if ch.PubDate != nil {
+		errs = append(errs, ch.PubDate.validate(path+`/pubDate`)...)
+	}
+	if ch.LastBuildDate != nil {
+		errs = append(errs, ch.LastBuildDate.validate(path+`/lastBuildDate`)...)
+	}
+	for i, category := range ch.Categories {
+		errs = append(errs, category.validate(indexPath(path, `category`, i))...)
+	}
+	if ch.Cloud != nil {
+		errs = append(errs, ch.Cloud.validate(path+`/cloud`)...)
+	}
+	if ch.TTL < 0 {
+		errs.add(path+`/ttl`, `must not be negative`)
+	}
+	if ch.Image != nil {
+		errs = append(errs, ch.Image.validate(path+`/image`)...)
+	}
+	if ch.TextInput != nil {
+		errs = append(errs, ch.TextInput.validate(path+`/textInput`)...)
+	}
+	if ch.SkipHours != nil {
+		errs = append(errs, ch.SkipHours.validate(path+`/skipHours`)...)
+	}
+	if ch.SkipDays != nil {
+		errs = append(errs, ch.SkipDays.validate(path+`/skipDays`)...)
+	}
+	for i, link := range ch.AtomLinks {
+		errs = append(errs, link.validate(indexPath(path, `atom:link`, i))...)
+	}
+	if ch.DC != nil {
+		errs = append(errs, ch.DC.validate(path)...)
+	}
+	if ch.ITunes != nil {
+		errs = append(errs, ch.ITunes.validate(path)...)
+	}
+	if ch.Podcast != nil {
+		errs = append(errs, ch.Podcast.validate(path)...)
+	}
+	for i, item := range ch.Items {
+		errs = append(errs, item.validate(indexPath(path, `item`, i))...)
+	}
+	return
+}
+
+// namespaces adds the namespaces of the extensions used by ch and its
+// items to used.
+func (ch *Channel) namespaces(used map[string]bool) {
+	if len(ch.AtomLinks) > 0 {
+		used[AtomNamespace] = true
+	}
+	if ch.DC != nil {
+		used[DublinCoreNamespace] = true
+	}
+	if ch.ITunes != nil {
+		used[ITunesNamespace] = true
+	}
+	if ch.Podcast != nil {
+		used[PodcastNamespace] = true
+	}
+	for _, el := range ch.Extensions {
+		el.namespaces(used)
+	}
+	for _, attr := range ch.Attrs {
+		markPrefixUsed(used, attr.Name.Local)
+	}
+	for _, item := range ch.Items {
+		item.namespaces(used)
+	}
+}
+
+// AtomLink returns the first of the channel's AtomLinks with the given rel or
+// nil, if there is none.
+func (ch *Channel) AtomLink(rel string) *AtomLink {
+	return findAtomLink(ch.AtomLinks, rel)
+}
+
+// Extension returns the first of the channel's Extensions with the given
+// name or nil, if there is none.
+func (ch *Channel) Extension(name string) *Element {
+	return findElement(ch.Extensions, name)
+}
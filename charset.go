@@ -0,0 +1,207 @@
+package rss2
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// iso885915 contains the characters of ISO-8859-15, that differ from
+// ISO-8859-1.
+var iso885915 = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž',
+	0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+// windows1252 contains the characters of Windows-1252, that differ
+// from ISO-8859-1. The unassigned bytes 0x81, 0x8D, 0x8F, 0x90 and
+// 0x9D are left as they are in ISO-8859-1.
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„',
+	0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ',
+	0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// CharsetReader returns a reader, that converts input from the given
+// charset to UTF-8. It can be used as the CharsetReader of an
+// xml.Decoder. Supported are US-ASCII, ISO-8859-1, ISO-8859-15,
+// Windows-1252 and UTF-16. UTF-16 input is expected to start with a
+// byte order mark and is read as big endian otherwise.
+//
+// The parse functions of this package already use CharsetReader and
+// additionally detect UTF-16 documents, which don't declare their
+// encoding in a way xml.Decoder could read.
+func CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case `utf-8`, `utf8`, `us-ascii`, `ascii`:
+		return input, nil
+	case `iso-8859-1`, `iso8859-1`, `iso_8859-1`, `latin1`, `latin-1`, `l1`:
+		return &singleByteReader{r: input}, nil
+	case `iso-8859-15`, `iso8859-15`, `iso_8859-15`, `latin9`, `latin-9`:
+		return &singleByteReader{r: input, table: iso885915}, nil
+	case `windows-1252`, `cp1252`, `x-cp1252`:
+		return &singleByteReader{r: input, table: windows1252}, nil
+	case `utf-16`, `utf16`:
+		return newUTF16Reader(bufio.NewReader(input), false), nil
+	case `utf-16be`:
+		return &utf16Reader{r: bufio.NewReader(input), littleEndian: false}, nil
+	case `utf-16le`:
+		return &utf16Reader{r: bufio.NewReader(input), littleEndian: true}, nil
+	}
+	return nil, fmt.Errorf(`unsupported charset '%s'`, charset)
+}
+
+// newUTF8Reader detects UTF-16 input by its byte order mark or its
+// first character, which must be '<' or whitespace in XML documents.
+// UTF-16 input is converted to UTF-8. The returned bool indicates
+// whether the input was converted. A UTF-8 byte order mark is removed.
+func newUTF8Reader(input io.Reader) (io.Reader, bool) {
+	r := bufio.NewReader(input)
+	head, _ := r.Peek(3)
+	switch {
+	case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+		r.Discard(3)
+		return r, false
+	case bytes.HasPrefix(head, []byte{0xFE, 0xFF}),
+		bytes.HasPrefix(head, []byte{0xFF, 0xFE}):
+		return newUTF16Reader(r, false), true
+	case len(head) >= 2 && head[0] == 0 && head[1] != 0:
+		return &utf16Reader{r: r, littleEndian: false}, true
+	case len(head) >= 2 && head[0] != 0 && head[1] == 0:
+		return &utf16Reader{r: r, littleEndian: true}, true
+	}
+	return r, false
+}
+
+// newUTF16Reader consumes a byte order mark, if present, and returns a
+// reader with the corresponding endianness.
+func newUTF16Reader(r *bufio.Reader, littleEndian bool) *utf16Reader {
+	head, _ := r.Peek(2)
+	if bytes.Equal(head, []byte{0xFE, 0xFF}) {
+		r.Discard(2)
+		littleEndian = false
+	} else if bytes.Equal(head, []byte{0xFF, 0xFE}) {
+		r.Discard(2)
+		littleEndian = true
+	}
+	return &utf16Reader{r: r, littleEndian: littleEndian}
+}
+
+// singleByteReader converts a single byte charset to UTF-8. Bytes not
+// contained in table are interpreted as ISO-8859-1.
+type singleByteReader struct {
+	r     io.Reader
+	table map[byte]rune
+	buf   []byte
+	out   []byte
+}
+
+func (s *singleByteReader) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		if cap(s.buf) == 0 {
+			s.buf = make([]byte, 2048)
+		}
+		n, err := s.r.Read(s.buf[:cap(s.buf)])
+		for _, b := range s.buf[:n] {
+			if r, ok := s.table[b]; ok {
+				s.out = appendRune(s.out, r)
+			} else if b < utf8.RuneSelf {
+				s.out = append(s.out, b)
+			} else {
+				s.out = appendRune(s.out, rune(b))
+			}
+		}
+		if err != nil && len(s.out) == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+// utf16Reader converts UTF-16 to UTF-8. Unpaired surrogates are
+// replaced with utf8.RuneError.
+type utf16Reader struct {
+	r            *bufio.Reader
+	littleEndian bool
+	out          []byte
+	err          error
+	// pending is a unit, that has been read after a high surrogate,
+	// but is not a low surrogate.
+	pending    rune
+	hasPending bool
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.out) < len(p) && u.err == nil {
+		var r1 rune
+		if r1, u.err = u.nextUnit(); u.err != nil {
+			break
+		}
+		if isHighSurrogate(r1) {
+			if r2, err := u.nextUnit(); err != nil {
+				r1, u.err = utf8.RuneError, err
+			} else if isLowSurrogate(r2) {
+				r1 = utf16.DecodeRune(r1, r2)
+			} else {
+				r1, u.pending, u.hasPending = utf8.RuneError, r2, true
+			}
+		} else if isLowSurrogate(r1) {
+			r1 = utf8.RuneError
+		}
+		u.out = appendRune(u.out, r1)
+	}
+	if len(u.out) == 0 {
+		return 0, u.err
+	}
+	n := copy(p, u.out)
+	u.out = u.out[n:]
+	return n, nil
+}
+
+// nextUnit returns the pending unit, if there is one, or reads the next
+// one.
+func (u *utf16Reader) nextUnit() (rune, error) {
+	if u.hasPending {
+		u.hasPending = false
+		return u.pending, nil
+	}
+	return u.readUnit()
+}
+
+func isHighSurrogate(r rune) bool {
+	return 0xD800 <= r && r < 0xDC00
+}
+
+func isLowSurrogate(r rune) bool {
+	return 0xDC00 <= r && r < 0xE000
+}
+
+func (u *utf16Reader) readUnit() (rune, error) {
+	var unit [2]byte
+	if _, err := io.ReadFull(u.r, unit[:]); err == io.ErrUnexpectedEOF {
+		return utf8.RuneError, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if u.littleEndian {
+		return rune(unit[1])<<8 | rune(unit[0]), nil
+	}
+	return rune(unit[0])<<8 | rune(unit[1]), nil
+}
+
+// appendRune appends the UTF-8 encoding of r to p.
+func appendRune(p []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(p, buf[:n]...)
+}
@@ -0,0 +1,84 @@
+package rss2
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func TestParseCharsets(t *testing.T) {
+	testCases := map[string]string{
+		`ISO-8859-1`:   "Caf\xe9 \xa4",
+		`iso-8859-15`:  "Caf\xe9 \xa4",
+		`windows-1252`: "Caf\xe9 \x80 \x93quoted\x94",
+	}
+	expected := map[string]string{
+		`ISO-8859-1`:   `Café ¤`,
+		`iso-8859-15`:  `Café €`,
+		`windows-1252`: `Café € “quoted”`,
+	}
+	for charset, title := range testCases {
+		input := `<?xml version="1.0" encoding="` + charset + `"?>` +
+			`<rss version="2.0"><channel><title>` + title +
+			`</title></channel></rss>`
+		parse, err := Parse([]byte(input))
+		if err != nil {
+			t.Errorf("Error parsing %s: %s", charset, err.Error())
+		} else if parse.Channel.Title != expected[charset] {
+			t.Errorf("Parsing %s yielded '%s'. Expected '%s'", charset,
+				parse.Channel.Title, expected[charset])
+		}
+	}
+}
+
+func TestParseUTF16(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-16"?>` +
+		`<rss version="2.0"><channel><title>Grüße 𝄞</title></channel></rss>`
+	units := utf16.Encode([]rune(input))
+	bigEndian := []byte{0xFE, 0xFF}
+	littleEndian := []byte{0xFF, 0xFE}
+	for _, unit := range units {
+		bigEndian = append(bigEndian, byte(unit>>8), byte(unit))
+		littleEndian = append(littleEndian, byte(unit), byte(unit>>8))
+	}
+	testCases := map[string][]byte{
+		`big endian with BOM`:       bigEndian,
+		`little endian with BOM`:    littleEndian,
+		`big endian without BOM`:    bigEndian[2:],
+		`little endian without BOM`: littleEndian[2:],
+	}
+	for name, in := range testCases {
+		parse, err := Parse(in)
+		if err != nil {
+			t.Errorf("Error parsing %s: %s", name, err.Error())
+		} else if parse.Channel.Title != `Grüße 𝄞` {
+			t.Errorf("Parsing %s yielded '%s'", name, parse.Channel.Title)
+		}
+	}
+}
+
+func TestParseUTF16UnpairedSurrogates(t *testing.T) {
+	var units []uint16
+	units = append(units, utf16.Encode([]rune(`<rss version="2.0"><channel><title>a`))...)
+	// A high surrogate followed by a character, a lone low surrogate and
+	// a high surrogate followed by another high surrogate and its pair.
+	units = append(units, 0xD800, 'b', 0xDC00, 'c', 0xD800, 0xD834, 0xDD1E)
+	units = append(units, utf16.Encode([]rune(`</title></channel></rss>`))...)
+	in := []byte{0xFE, 0xFF}
+	for _, unit := range units {
+		in = append(in, byte(unit>>8), byte(unit))
+	}
+	parse, err := Parse(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "a\uFFFDb\uFFFDc\uFFFD𝄞"; parse.Channel.Title != expected {
+		t.Errorf("Parsing yielded '%s'. Expected '%s'", parse.Channel.Title, expected)
+	}
+}
+
+func TestParseUnsupportedCharset(t *testing.T) {
+	input := `<?xml version="1.0" encoding="KOI8-R"?><rss version="2.0"><channel/></rss>`
+	if _, err := Parse([]byte(input)); err == nil {
+		t.Errorf("Expected error for unsupported charset")
+	}
+}
@@ -30,3 +30,14 @@ func NewCloud(domain string, port int, path, rp, protocol string) (*Cloud, error
 		Protocol:          protocol,
 	}, nil
 }
+
+func (c *Cloud) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@domain`, c.Domain)
+	if c.Port < 1 || c.Port > 65535 {
+		errs.add(path+`@port`, `%d is not a valid port`, c.Port)
+	}
+	errs.requireNonEmpty(path+`@path`, c.Path)
+	errs.requireNonEmpty(path+`@registerProcedure`, c.RegisterProcedure)
+	errs.requireNonEmpty(path+`@protocol`, c.Protocol)
+	return
+}
@@ -0,0 +1,30 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Content represents an Item's content:encoded element of the RSS
+// Content Module. Value is the full content of the item as HTML and is
+// rendered as CDATA.
+type Content struct {
+	XMLName xml.Name `xml:"content:encoded"`
+	Value   string   `xml:",cdata"`
+}
+
+// NewContent creates a new Content element.
+func NewContent(html string) (*Content, error) {
+	if len(html) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewContent()`)
+	}
+	return &Content{
+		XMLName: xml.Name{Local: `content:encoded`},
+		Value:   html,
+	}, nil
+}
+
+func (c *Content) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path, c.Value)
+	return
+}
@@ -0,0 +1,380 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Decoder reads an RSS document item by item, so that huge feeds can be
+// processed without holding all their items in memory. Header returns
+// the rss element and its channel without items, while Next returns one
+// item after the other. Reading can be stopped at any time.
+//
+// Documents are checked and upgraded like by Parse. The channel
+// elements preceding the first item are available through Header right
+// away. Channel elements following the items are added to the channel
+// returned by Header, once Next has returned io.EOF.
+type Decoder struct {
+	decoder  *xml.Decoder
+	tokens   *prefixReader
+	state    *decodeState
+	rss      *RSS
+	rdf      bool
+	warnings Warnings
+	started  bool
+	done     bool
+	next     *xml.StartElement
+	trailing []xml.Token
+	err      error
+}
+
+// NewDecoder creates a new Decoder reading from r. Documents that are
+// not UTF-8 encoded are converted, if their encoding is supported by
+// CharsetReader.
+func NewDecoder(r io.Reader) *Decoder {
+	return ParseOptions{}.NewDecoder(r)
+}
+
+// NewDecoder is like the package level NewDecoder, but the returned
+// Decoder respects o. Non-fatal problems are returned as Warnings by
+// Header, if they concern the channel, or by Next, together with the
+// affected item.
+func (o ParseOptions) NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{decoder: o.newDecoder(r), state: &decodeState{opts: o}}
+}
+
+// Header returns the rss element and its channel, without any items. The
+// same RSS is returned on every call. An error is returned if the
+// document is not well formed, its root element is neither rss nor
+// rdf:RDF, its version is not supported or no channel precedes the
+// items.
+func (d *Decoder) Header() (*RSS, error) {
+	if !d.started {
+		d.started = true
+		d.err = d.readHeader()
+	}
+	if d.rss == nil {
+		return nil, d.err
+	}
+	if len(d.warnings) > 0 {
+		return d.rss, d.warnings
+	}
+	return d.rss, nil
+}
+
+// Next returns the next item of the channel. io.EOF is returned after
+// the last item. Items of RDF documents are upgraded like by Parse.
+func (d *Decoder) Next() (*Item, error) {
+	if _, err := d.Header(); d.rss == nil {
+		return nil, err
+	}
+	if d.err != nil {
+		return nil, d.err
+	} else if d.done {
+		return nil, io.EOF
+	}
+	item, err := d.readItem()
+	if err == nil {
+		return item, nil
+	} else if _, ok := err.(Warnings); ok {
+		return item, err
+	}
+	d.err = err
+	return nil, err
+}
+
+// readHeader reads the root element and all elements preceding the
+// first item. The start of the first item is kept in d.next.
+func (d *Decoder) readHeader() error {
+	root, err := rootElement(d.decoder)
+	if err != nil {
+		return err
+	}
+	var version string
+	switch {
+	case root.Name.Local == `rss`:
+		for _, attr := range root.Attr {
+			if attr.Name.Local == `version` {
+				version = attr.Value
+			}
+		}
+		if !isLegacyVersion(version) && version != `2.0` {
+			return &VersionError{Version: version}
+		}
+		d.tokens = newPrefixReader(d.decoder, root, isLegacyVersion(version))
+	case root.Name == xml.Name{Space: rdfNamespace, Local: `RDF`}:
+		d.rdf = true
+		d.tokens = newPrefixReader(d.decoder, root, true)
+	default:
+		return &RootElementError{Name: root.Name}
+	}
+	prefixedRoot, err := d.tokens.Token()
+	if err != nil {
+		return err
+	}
+	header := []xml.Token{prefixedRoot}
+	if !d.rdf {
+		// The items are children of the channel in RSS 2.0, but of the
+		// root element in RDF documents.
+		channel, err := d.findChannel()
+		if err != nil {
+			return err
+		}
+		header = append(header, channel)
+	}
+	for d.next == nil {
+		token, err := d.token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == `item` {
+				d.next = &t
+				continue
+			}
+			subtree, err := d.copySubtree(t)
+			if err != nil {
+				return err
+			}
+			header = append(header, subtree...)
+		case xml.EndElement:
+			// There are no items.
+			d.done = true
+			if err := d.drain(); err != nil {
+				return err
+			}
+			return d.decodeHeader(header, version)
+		}
+	}
+	return d.decodeHeader(header, version)
+}
+
+// findChannel skips the elements preceding the channel and returns its
+// start.
+func (d *Decoder) findChannel() (xml.StartElement, error) {
+	for {
+		token, err := d.tokens.Token()
+		if err == io.EOF {
+			return xml.StartElement{}, ErrNoChannel
+		} else if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local == `channel` {
+				return start, nil
+			}
+			if _, err := d.copySubtree(start); err != nil {
+				return xml.StartElement{}, err
+			}
+		}
+	}
+}
+
+// decodeHeader decodes the tokens of the root element and the channel,
+// which lack their end elements, into d.rss.
+func (d *Decoder) decodeHeader(header []xml.Token, version string) error {
+	if !d.rdf {
+		header = append(header, header[1].(xml.StartElement).End())
+	}
+	header = append(header, header[0].(xml.StartElement).End())
+	if d.rdf {
+		var doc rdf
+		if err := d.decodeTokens(header, &doc); err != nil {
+			return err
+		}
+		if doc.Channel == nil {
+			return ErrNoChannel
+		}
+		d.rss = doc.upgrade(rdfVersion(header[0].(xml.StartElement)))
+		return nil
+	}
+	type rss RSS
+	d.rss = &RSS{}
+	if err := d.decodeTokens(header, (*rss)(d.rss)); err != nil {
+		d.rss = nil
+		return err
+	}
+	if isLegacyVersion(version) {
+		d.rss.upgrade()
+	}
+	return nil
+}
+
+// readItem reads the next item. Other elements are collected in
+// d.trailing and decoded, once the end of the channel is reached.
+func (d *Decoder) readItem() (*Item, error) {
+	for d.next == nil {
+		token, err := d.token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == `item` {
+				d.next = &t
+				continue
+			}
+			subtree, err := d.copySubtree(t)
+			if err != nil {
+				return nil, err
+			}
+			d.trailing = append(d.trailing, subtree...)
+		case xml.EndElement:
+			if err := d.drain(); err != nil {
+				return nil, err
+			}
+			if err := d.decodeTrailing(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+	}
+	item := &Item{}
+	items := &subtreeReader{r: d.tokens, start: d.next}
+	d.next = nil
+	d.state.warnings = nil
+	if err := d.decode(xml.NewTokenDecoder(items), item); err != nil {
+		return nil, err
+	}
+	if d.rdf {
+		item.upgradeRDF()
+	}
+	if len(d.state.warnings) > 0 {
+		return item, d.state.warnings
+	}
+	return item, nil
+}
+
+// decodeTrailing adds the elements following the items to the channel.
+// Their warnings are added to those returned by Header.
+func (d *Decoder) decodeTrailing() error {
+	if len(d.trailing) == 0 {
+		return nil
+	}
+	if d.rdf {
+		var doc rdf
+		tokens := append([]xml.Token{xml.StartElement{Name: xml.Name{Local: `RDF`}}}, d.trailing...)
+		if err := d.decodeTokens(append(tokens, xml.EndElement{Name: xml.Name{Local: `RDF`}}), &doc); err != nil {
+			return err
+		}
+		if doc.Image != nil {
+			d.rss.Channel.Image = doc.Image
+		}
+		if doc.TextInput != nil {
+			d.rss.Channel.TextInput = doc.TextInput
+		}
+		return nil
+	}
+	tokens := append([]xml.Token{xml.StartElement{Name: xml.Name{Local: `channel`}}}, d.trailing...)
+	if err := d.decodeTokens(append(tokens, xml.EndElement{Name: xml.Name{Local: `channel`}}), d.rss.Channel); err != nil {
+		return err
+	}
+	if isLegacyVersion(d.rss.SourceVersion) {
+		d.rss.Channel.upgradeSkipHours()
+	}
+	return nil
+}
+
+// decodeTokens decodes the element formed by tokens into v. Warnings
+// are collected in d.warnings.
+func (d *Decoder) decodeTokens(tokens []xml.Token, v interface{}) error {
+	d.state.warnings = nil
+	err := d.decode(xml.NewTokenDecoder(&tokenSlice{tokens: tokens}), v)
+	d.warnings = append(d.warnings, d.state.warnings...)
+	return err
+}
+
+// decode decodes the next element of decoder into v, respecting the
+// options of d.
+func (d *Decoder) decode(decoder *xml.Decoder, v interface{}) error {
+	decodeStates.Store(decoder, d.state)
+	defer decodeStates.Delete(decoder)
+	return decoder.Decode(v)
+}
+
+// token returns the next token of the element containing the items.
+func (d *Decoder) token() (xml.Token, error) {
+	token, err := d.tokens.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return token, err
+}
+
+// copySubtree returns copies of the tokens of the element started by
+// start.
+func (d *Decoder) copySubtree(start xml.StartElement) ([]xml.Token, error) {
+	tokens := []xml.Token{start.Copy()}
+	for depth := 1; depth > 0; {
+		token, err := d.token()
+		if err != nil {
+			return nil, err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+		tokens = append(tokens, xml.CopyToken(token))
+	}
+	return tokens, nil
+}
+
+// drain reads the remaining tokens of the root element, so that errors
+// in the rest of the document are detected.
+func (d *Decoder) drain() error {
+	for {
+		if _, err := d.tokens.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// subtreeReader is an xml.TokenReader that reads the element started
+// by start from r.
+type subtreeReader struct {
+	r     xml.TokenReader
+	start *xml.StartElement
+	depth int
+}
+
+func (s *subtreeReader) Token() (xml.Token, error) {
+	var token xml.Token
+	if s.start != nil {
+		token, s.start = *s.start, nil
+	} else if s.depth == 0 {
+		return nil, io.EOF
+	} else {
+		var err error
+		if token, err = s.r.Token(); err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	switch token.(type) {
+	case xml.StartElement:
+		s.depth++
+	case xml.EndElement:
+		s.depth--
+	}
+	return token, nil
+}
+
+// tokenSlice is an xml.TokenReader that reads tokens from a slice.
+type tokenSlice struct {
+	tokens []xml.Token
+}
+
+func (s *tokenSlice) Token() (xml.Token, error) {
+	if len(s.tokens) == 0 {
+		return nil, io.EOF
+	}
+	token := s.tokens[0]
+	s.tokens = s.tokens[1:]
+	return token, nil
+}
@@ -0,0 +1,146 @@
+package rss2
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// decodeAll reads the whole document with a Decoder and returns the
+// result in the form of Parse.
+func decodeAll(input string) (*RSS, error) {
+	decoder := NewDecoder(strings.NewReader(input))
+	rss, err := decoder.Header()
+	if err != nil {
+		return nil, err
+	}
+	var items []*Item
+	for {
+		item, err := decoder.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	rss.Channel.Items = items
+	return rss, nil
+}
+
+func TestDecoder(t *testing.T) {
+	inputs := []string{`<rss version="2.0" xmlns:slash="http://purl.org/rss/1.0/modules/slash/">
+  <channel>
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+    <item><title>Item 1</title><slash:comments>3</slash:comments></item>
+    <item><title>Item 2</title></item>
+    <ttl>60</ttl>
+    <category>Trailing</category>
+  </channel>
+</rss>`, `<rss version="0.91">
+  <channel>
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+    <item><title>Item 1</title></item>
+    <skipHours><hour>24</hour></skipHours>
+  </channel>
+</rss>`, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+    xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="http://purl.org/rss/1.0/">
+  <channel rdf:about="https://foo.com/feed.rdf">
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+  </channel>
+  <item rdf:about="https://foo.com/1">
+    <title>Item 1</title>
+    <link>https://foo.com/1</link>
+    <dc:creator>Jane Doe</dc:creator>
+  </item>
+  <textinput rdf:about="https://foo.com/search">
+    <title>Search</title>
+    <description>Search the site</description>
+    <name>q</name>
+    <link>https://foo.com/search</link>
+  </textinput>
+</rdf:RDF>`, `<rss version="2.0"><channel><title>No items</title></channel></rss>`}
+	for _, tc := range xmlToRSSTestCases {
+		inputs = append(inputs, tc.Input)
+	}
+	for _, input := range inputs {
+		expected, err := Parse([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := decodeAll(input)
+		if err != nil {
+			t.Errorf("Failed to decode: %v", err)
+			continue
+		}
+		if diff := cmp.Diff(expected, decoded); diff != "" {
+			t.Errorf("Decoder mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestDecoderStopEarly(t *testing.T) {
+	// The document is truncated after the fifth item, but only three
+	// items are read.
+	input := `<rss version="2.0"><channel><title>Channel title</title>` +
+		strings.Repeat(`<item><title>Item</title></item>`, 5)
+	decoder := NewDecoder(strings.NewReader(input))
+	rss, err := decoder.Header()
+	if err != nil {
+		t.Fatal(err)
+	} else if rss.Channel.Title != `Channel title` {
+		t.Errorf("Unexpected channel title '%s'", rss.Channel.Title)
+	}
+	for i := 0; i < 3; i++ {
+		if item, err := decoder.Next(); err != nil {
+			t.Fatal(err)
+		} else if item.Title != `Item` {
+			t.Errorf("Unexpected item title '%s'", item.Title)
+		}
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	var rootErr *RootElementError
+	if _, err := NewDecoder(strings.NewReader(`<feed></feed>`)).Header(); !errors.As(err, &rootErr) {
+		t.Errorf("Expected RootElementError, got '%v'", err)
+	}
+	var versionErr *VersionError
+	if _, err := NewDecoder(strings.NewReader(`<rss version="3.0"><channel></channel></rss>`)).Next(); !errors.As(err, &versionErr) {
+		t.Errorf("Expected VersionError, got '%v'", err)
+	}
+	if _, err := NewDecoder(strings.NewReader(`<rss version="2.0"></rss>`)).Header(); err != ErrNoChannel {
+		t.Errorf("Expected ErrNoChannel, got '%v'", err)
+	}
+	decoder := NewDecoder(strings.NewReader(`<rss version="2.0"><channel><item><title>1</title></item><item>`))
+	if _, err := decoder.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decoder.Next(); err == nil || err == io.EOF {
+		t.Errorf("Expected error for truncated item, got '%v'", err)
+	}
+
+	decoder = ParseOptions{KeepInvalidDates: true}.NewDecoder(strings.NewReader(`<rss version="2.0"><channel>
+		<item><pubDate>yesterday</pubDate></item><item><title>2</title></item></channel></rss>`))
+	var warnings Warnings
+	if item, err := decoder.Next(); !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Errorf("Expected one warning, got '%v'", err)
+	} else if item.PubDate.Raw != `yesterday` {
+		t.Errorf("Invalid date was not kept")
+	}
+	if _, err := decoder.Next(); err != nil {
+		t.Errorf("Unexpected error '%v'", err)
+	}
+	if _, err := decoder.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got '%v'", err)
+	}
+}
@@ -0,0 +1,186 @@
+package rss2
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	atom03Namespace   = `http://purl.org/atom/ns#`
+	jsonFeedVersionID = `https://jsonfeed.org/version/`
+)
+
+// ErrUnknownFormat is returned by Detect and the ParseAny functions, if
+// a document is not a feed of a known format.
+var ErrUnknownFormat = errors.New(`unknown feed format`)
+
+// Format is a feed format, as reported by Detect.
+type Format int
+
+// The formats reported by Detect.
+const (
+	FormatUnknown Format = iota
+	FormatRSS
+	FormatRDF
+	FormatAtom
+	FormatJSONFeed
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatRSS:
+		return `RSS`
+	case FormatRDF:
+		return `RDF`
+	case FormatAtom:
+		return `Atom`
+	case FormatJSONFeed:
+		return `JSON Feed`
+	}
+	return `unknown`
+}
+
+// Detect reads the beginning of a document from r and reports its
+// format and version. The version is that of the format, e.g. "2.0" or
+// "0.91" for RSS, "1.0" or "0.90" for RDF, "1.0" or "0.3" for Atom and
+// "1" or "1.1" for JSON Feed. The version of RSS is reported as given
+// in the document, even if it is not supported.
+//
+// Detect does not rely on MIME types or file extensions, but on the
+// root element of XML documents and the version of JSON documents.
+// ErrUnknownFormat is returned, if the document is of neither format.
+func Detect(r io.Reader) (Format, string, error) {
+	br := bufio.NewReader(r)
+	for {
+		head, err := br.Peek(3)
+		if len(head) == 0 {
+			if err == io.EOF {
+				return FormatUnknown, ``, ErrUnknownFormat
+			}
+			return FormatUnknown, ``, err
+		}
+		switch {
+		case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+			br.Discard(3)
+		case strings.IndexByte(" \t\r\n", head[0]) >= 0:
+			br.Discard(1)
+		case head[0] == '{':
+			return detectJSON(br)
+		default:
+			return detectXML(br)
+		}
+	}
+}
+
+func detectJSON(r io.Reader) (Format, string, error) {
+	decoder := json.NewDecoder(r)
+	if _, err := decoder.Token(); err != nil {
+		return FormatUnknown, ``, err
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return FormatUnknown, ``, err
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return FormatUnknown, ``, err
+		}
+		var version string
+		if key == `version` && json.Unmarshal(value, &version) == nil &&
+			strings.HasPrefix(version, jsonFeedVersionID) {
+			return FormatJSONFeed, strings.TrimPrefix(version, jsonFeedVersionID), nil
+		}
+	}
+	return FormatUnknown, ``, ErrUnknownFormat
+}
+
+func detectXML(r io.Reader) (Format, string, error) {
+	start, err := rootElement(newDecoder(r))
+	var rootErr *RootElementError
+	if errors.As(err, &rootErr) {
+		return FormatUnknown, ``, ErrUnknownFormat
+	} else if err != nil {
+		return FormatUnknown, ``, err
+	}
+	switch start.Name {
+	case xml.Name{Space: rdfNamespace, Local: `RDF`}:
+		return FormatRDF, rdfVersion(start), nil
+	case xml.Name{Space: AtomNamespace, Local: `feed`}:
+		return FormatAtom, `1.0`, nil
+	case xml.Name{Space: atom03Namespace, Local: `feed`}:
+		return FormatAtom, `0.3`, nil
+	}
+	if start.Name.Local == `rss` {
+		for _, attr := range start.Attr {
+			if attr.Name.Local == `version` {
+				return FormatRSS, attr.Value, nil
+			}
+		}
+		return FormatRSS, ``, nil
+	}
+	return FormatUnknown, ``, ErrUnknownFormat
+}
+
+// ParseAny detects the format of a document with Detect and parses it
+// with Parse, ParseAtom or ParseJSONFeed accordingly. Thus the result is
+// always given in the RSS 2.0 model. Documents of unsupported versions,
+// like Atom 0.3, yield a VersionError.
+func ParseAny(data []byte) (*RSS, error) {
+	return ParseOptions{}.ParseAny(data)
+}
+
+// ParseAnyFile parses the document stored at path. See ParseAny.
+func ParseAnyFile(path string) (*RSS, error) {
+	return ParseOptions{}.ParseAnyFile(path)
+}
+
+// ParseAnyReader parses a document read from r. See ParseAny.
+func ParseAnyReader(r io.Reader) (*RSS, error) {
+	return ParseOptions{}.ParseAnyReader(r)
+}
+
+// ParseAny is like the package level ParseAny, but respects o.
+func (o ParseOptions) ParseAny(data []byte) (*RSS, error) {
+	return o.ParseAnyReader(bytes.NewReader(data))
+}
+
+// ParseAnyFile is like the package level ParseAnyFile, but respects o.
+func (o ParseOptions) ParseAnyFile(path string) (*RSS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return o.ParseAnyReader(f)
+}
+
+// ParseAnyReader is like the package level ParseAnyReader, but respects
+// o.
+func (o ParseOptions) ParseAnyReader(r io.Reader) (*RSS, error) {
+	// The limit applies to Detect as well, since it may read a large
+	// part of the document. That part is read again when parsing.
+	r = o.limitReader(r)
+	var head bytes.Buffer
+	format, version, err := Detect(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, err
+	}
+	r = io.MultiReader(&head, r)
+	switch format {
+	case FormatAtom:
+		if version != `1.0` {
+			return nil, &VersionError{Version: version}
+		}
+		return o.ParseAtomReader(r)
+	case FormatJSONFeed:
+		return o.ParseJSONFeedReader(r)
+	}
+	return o.ParseReader(r)
+}
@@ -0,0 +1,58 @@
+package rss2
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var detectTestCases = []struct {
+	Input   string
+	Format  Format
+	Version string
+}{
+	{`<?xml version="1.0"?><rss version="2.0"><channel><title>RSS</title></channel></rss>`, FormatRSS, `2.0`},
+	{"\xEF\xBB\xBF\n<!-- comment --><rss version=\"0.91\"><channel><title>RSS</title></channel></rss>", FormatRSS, `0.91`},
+	{`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+<channel><title>RDF</title></channel></rdf:RDF>`, FormatRDF, `1.0`},
+	{`<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title></feed>`, FormatAtom, `1.0`},
+	{`<feed version="0.3" xmlns="http://purl.org/atom/ns#"><title>Atom</title></feed>`, FormatAtom, `0.3`},
+	{`  {"items": [{"id": "1"}], "version": "https://jsonfeed.org/version/1.1", "title": "JSON Feed"}`, FormatJSONFeed, `1.1`},
+}
+
+func TestDetect(t *testing.T) {
+	for _, tc := range detectTestCases {
+		format, version, err := Detect(strings.NewReader(tc.Input))
+		if err != nil {
+			t.Errorf("Unexpected error for '%s': %v", tc.Input, err)
+		} else if format != tc.Format || version != tc.Version {
+			t.Errorf("Detected %s %s instead of %s %s", format, version, tc.Format, tc.Version)
+		}
+	}
+	for _, input := range []string{``, `plain text`, `<html><body></body></html>`, `{"version": 1}`} {
+		if _, _, err := Detect(strings.NewReader(input)); err != ErrUnknownFormat {
+			t.Errorf("Expected ErrUnknownFormat for '%s', got '%v'", input, err)
+		}
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	for _, tc := range detectTestCases {
+		rss, err := ParseAnyReader(strings.NewReader(tc.Input))
+		if tc.Version == `0.3` {
+			var versionErr *VersionError
+			if !errors.As(err, &versionErr) {
+				t.Errorf("Expected VersionError for Atom 0.3, got '%v'", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for '%s': %v", tc.Input, err)
+		} else if rss.Version != `2.0` || !strings.HasPrefix(rss.Channel.Title, tc.Format.String()) {
+			t.Errorf("Unexpected result for '%s': %v", tc.Input, rss.Channel)
+		}
+	}
+	if _, err := ParseAny([]byte(`<html></html>`)); err != ErrUnknownFormat {
+		t.Errorf("Expected ErrUnknownFormat, got '%v'", err)
+	}
+}
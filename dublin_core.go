@@ -0,0 +1,22 @@
+package rss2
+
+// DublinCore holds the elements of the Dublin Core Metadata Element Set
+// that are commonly used in channels and items. They are often used
+// instead of the author element, which must contain an email address,
+// and instead of pubDate.
+type DublinCore struct {
+	Creators   []string `xml:"dc:creator,omitempty"`
+	Date       *W3CTime `xml:"dc:date,omitempty"`
+	Subjects   []string `xml:"dc:subject,omitempty"`
+	Rights     string   `xml:"dc:rights,omitempty"`
+	Language   string   `xml:"dc:language,omitempty"`
+	Publisher  string   `xml:"dc:publisher,omitempty"`
+	Identifier string   `xml:"dc:identifier,omitempty"`
+}
+
+func (dc *DublinCore) validate(path string) (errs ValidationErrors) {
+	if dc.Date != nil {
+		errs = append(errs, dc.Date.validate(path+`/dc:date`)...)
+	}
+	return
+}
@@ -0,0 +1,142 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Element represents an element, that is not known to this package,
+// e.g. of a third-party extension. Channels and Items keep such
+// elements, so that they are rendered again.
+//
+// Names of elements and attributes are kept in the prefixed form of
+// the parsed document, e.g. "slash:comments". The namespace
+// declarations of a parsed document are kept as well; see RSS.Attrs.
+// When creating elements of other namespaces, their declarations must
+// be added to RSS.Attrs. Alternatively, XMLName.Space can be set to
+// the namespace's URI, which declares it as the default namespace of
+// the element. Elements declaring a default namespace with an xmlns
+// attribute are kept as they are in the document, with an empty
+// XMLName.Space.
+//
+// Elements registered with RegisterExtension are decoded into Object
+// instead of Attrs, Value and Children.
+type Element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr  `xml:",any,attr"`
+	Value    string      `xml:",chardata"`
+	Children []*Element  `xml:",any"`
+	Object   interface{} `xml:"-"`
+}
+
+// NewElement creates a new Element with the given name and value.
+func NewElement(name, value string) (*Element, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf(`empty name passed to NewElement()`)
+	}
+	return &Element{XMLName: xml.Name{Local: name}, Value: value}, nil
+}
+
+// UnmarshalXML unmarshals an Element. The whitespace between the
+// children of an element is dropped.
+func (el *Element) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	if typ, ok := registeredTypes[start.Name.Local]; ok {
+		object := reflect.New(typ)
+		if err := decoder.DecodeElement(object.Interface(), &start); err != nil {
+			return err
+		}
+		*el = Element{XMLName: start.Name, Object: object.Interface()}
+		return nil
+	}
+	type element Element
+	if err := decoder.DecodeElement((*element)(el), &start); err != nil {
+		return err
+	}
+	if len(el.Children) > 0 && len(strings.TrimSpace(el.Value)) == 0 {
+		el.Value = ``
+	}
+	el.dropDefaultSpace(``)
+	return nil
+}
+
+// MarshalXML marshals an Element. If Object is set, it is encoded with
+// the name of the element instead of the other fields.
+func (el Element) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = el.XMLName
+	if el.Object != nil {
+		return e.EncodeElement(el.Object, start)
+	}
+	type element Element
+	return e.EncodeElement(element(el), start)
+}
+
+// dropDefaultSpace clears the Space of el and its children, where it
+// is declared by an xmlns attribute of el or one of its ancestors. The
+// attribute is kept and declares the namespace again when marshalling.
+func (el *Element) dropDefaultSpace(space string) {
+	for _, attr := range el.Attrs {
+		if attr.Name == (xml.Name{Local: `xmlns`}) {
+			space = attr.Value
+		}
+	}
+	if el.XMLName.Space == space {
+		el.XMLName.Space = ``
+	}
+	for _, child := range el.Children {
+		child.dropDefaultSpace(space)
+	}
+}
+
+// Attr returns the value of the element's attribute with the given
+// name or an empty string, if there is none.
+func (el *Element) Attr(name string) string {
+	for _, attr := range el.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ``
+}
+
+// Child returns the first of the element's children with the given name
+// or nil, if there is none.
+func (el *Element) Child(name string) *Element {
+	return findElement(el.Children, name)
+}
+
+func findElement(elements []*Element, name string) *Element {
+	for _, el := range elements {
+		if el.XMLName.Local == name {
+			return el
+		}
+	}
+	return nil
+}
+
+// namespaces adds the known namespaces used by the names of el and its
+// children to used.
+func (el *Element) namespaces(used map[string]bool) {
+	markPrefixUsed(used, el.XMLName.Local)
+	for _, attr := range el.Attrs {
+		markPrefixUsed(used, attr.Name.Local)
+	}
+	for _, child := range el.Children {
+		child.namespaces(used)
+	}
+}
+
+// markPrefixUsed adds the namespace of name to used, if name is
+// prefixed with the prefix of a known namespace.
+func markPrefixUsed(used map[string]bool, name string) {
+	i := strings.IndexByte(name, ':')
+	if i < 0 {
+		return
+	}
+	for namespace, prefix := range namespacePrefixes {
+		if prefix == name[:i] && namespace != xmlNamespace {
+			used[namespace] = true
+		}
+	}
+}
@@ -26,3 +26,12 @@ func NewEnclosure(url string, length int, t string) (*Enclosure, error) {
 		Type:    t,
 	}, nil
 }
+
+func (e *Enclosure) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@url`, e.URL)
+	if e.Length < 0 {
+		errs.add(path+`@length`, `must not be negative`)
+	}
+	errs.requireNonEmpty(path+`@type`, e.Type)
+	return
+}
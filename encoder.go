@@ -0,0 +1,133 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Encoder writes an RSS document item by item, so that huge feeds can
+// be rendered without holding all their items in memory. NewEncoder
+// writes the rss element and the channel, EncodeItem writes one item
+// after the other and Close finishes the document. The output is
+// identical to that of xml.Marshal for the RSS with all items added to
+// its channel or, if WithIndent is given, to that of xml.MarshalIndent.
+// Like with xml.Marshal, the XML declaration is not written.
+//
+// The namespaces declared on the rss element are written before any
+// item is known. Namespaces of known extensions, that are used by an
+// item but not declared on the rss element, are declared on the item
+// instead. To declare them on the rss element, as xml.Marshal does,
+// use RSS.DeclareNamespace beforehand.
+type Encoder struct {
+	w      io.Writer
+	config renderConfig
+	tail   []byte
+	// namespaces are those declared on the rss element.
+	namespaces map[string]bool
+	items      int
+	closed     bool
+}
+
+// NewEncoder validates r and writes the beginning of the document up to
+// the channel's items to w. The items of r's channel are written as
+// well. Nothing is written, if r is invalid. WithStylesheet cannot be
+// used, since stylesheets precede the rss element in the prolog, which
+// is left to the caller.
+func NewEncoder(w io.Writer, r *RSS, opts ...RenderOption) (*Encoder, error) {
+	e := &Encoder{w: w}
+	for _, opt := range opts {
+		opt(&e.config)
+	}
+	if len(e.config.stylesheets) > 0 {
+		return nil, fmt.Errorf(`WithStylesheet passed to NewEncoder()`)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	header := *r
+	channel := *r.Channel
+	channel.Items = nil
+	header.Channel = &channel
+	header.Attrs = append([]xml.Attr(nil), r.Attrs...)
+	for namespace := range r.namespaces() {
+		// Keep the namespaces used by the items of r.
+		header.DeclareNamespace(namespace)
+	}
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent(e.config.prefix, e.config.indent)
+	if err := encoder.Encode(&header); err != nil {
+		return nil, err
+	}
+	e.namespaces = header.namespaces()
+	// The items are written where the end of the channel would follow.
+	// When indenting, the end is on a line of its own, since a valid
+	// channel is never empty.
+	end := bytes.LastIndex(buf.Bytes(), []byte(`</channel>`))
+	if e.indented() {
+		end -= len("\n" + e.config.prefix + e.config.indent)
+	}
+	e.tail = append(e.tail, buf.Bytes()[end:]...)
+	if _, err := w.Write(buf.Bytes()[:end]); err != nil {
+		return nil, err
+	}
+	for _, item := range r.Channel.Items {
+		if err := e.EncodeItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// EncodeItem validates it and writes it to the document. An error is
+// returned if it is invalid. The namespaces of known extensions used by
+// it, that are not declared on the rss element, are declared on it.
+func (e *Encoder) EncodeItem(it *Item) error {
+	if e.closed {
+		return fmt.Errorf(`EncodeItem() called on closed Encoder`)
+	}
+	if errs := it.validate(indexPath(`channel`, `item`, e.items)); len(errs) > 0 {
+		return errs
+	}
+	used := make(map[string]bool)
+	it.namespaces(used)
+	for namespace := range used {
+		if e.namespaces[namespace] {
+			delete(used, namespace)
+		}
+	}
+	if len(used) > 0 {
+		declared := *it
+		declared.Attrs = append(namespaceAttrs(used), it.Attrs...)
+		it = &declared
+	}
+	var buf bytes.Buffer
+	if e.indented() {
+		buf.WriteByte('\n')
+	}
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent(e.config.prefix+e.config.indent+e.config.indent, e.config.indent)
+	if err := encoder.Encode(it); err != nil {
+		return err
+	}
+	e.items++
+	_, err := buf.WriteTo(e.w)
+	return err
+}
+
+func (e *Encoder) indented() bool {
+	return len(e.config.prefix) > 0 || len(e.config.indent) > 0
+}
+
+// Close writes the end of the document. It does not close the
+// underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	_, err := e.w.Write(e.tail)
+	return err
+}
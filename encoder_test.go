@@ -0,0 +1,160 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncoder(t *testing.T) {
+	var feeds []*RSS
+	for _, tc := range xmlToRSSTestCases {
+		rss := tc.Expected
+		if rss.Validate() == nil {
+			feeds = append(feeds, &rss)
+		}
+	}
+	item, _ := NewItem(`Item 1`, ``)
+	item.DC = &DublinCore{Creators: []string{`Jane Doe`}}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Items = []*Item{item, item}
+	feeds = append(feeds, NewRSS(channel))
+	if len(feeds) < 2 {
+		t.Fatal("Too few valid feeds")
+	}
+	for _, rss := range feeds {
+		for _, opts := range [][]RenderOption{nil, {WithIndent(`>`, "\t")}} {
+			var expected []byte
+			var err error
+			if opts == nil {
+				expected, err = xml.Marshal(rss)
+			} else {
+				expected, err = xml.MarshalIndent(rss, `>`, "\t")
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			header := *rss
+			channel := *rss.Channel
+			channel.Items = nil
+			header.Channel = &channel
+			header.Attrs = nil
+			for namespace := range rss.namespaces() {
+				if err := header.DeclareNamespace(namespace); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var buf bytes.Buffer
+			encoder, err := NewEncoder(&buf, &header, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range rss.Channel.Items {
+				if err := encoder.EncodeItem(item); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(expected), buf.String()); diff != "" {
+				t.Errorf("Encoder mismatch (-want +got):\n%s", diff)
+			}
+		}
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	rss := NewRSS(channel)
+	var buf bytes.Buffer
+	encoder, err := NewEncoder(&buf, rss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.EncodeItem(&Item{}); err == nil {
+		t.Errorf("Expected error for invalid item")
+	}
+	item, _ := NewItem(`Item 1`, ``)
+	item.Content, _ = NewContent(`Content`)
+	if err := encoder.EncodeItem(item); err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<rss version="2.0"><channel><title>Channel title</title><link>foo.com</link><description>Channel description</description>` +
+		`<item xmlns:content="http://purl.org/rss/1.0/modules/content/"><title>Item 1</title><content:encoded><![CDATA[Content]]></content:encoded></item></channel></rss>`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("Encoder mismatch (-want +got):\n%s", diff)
+	}
+	if err := encoder.EncodeItem(item); err == nil {
+		t.Errorf("Expected error for closed Encoder")
+	}
+	if err := rss.DeclareNamespace(`https://foo.com/unknown`); err == nil {
+		t.Errorf("Expected error for unknown namespace")
+	}
+	if _, err := NewEncoder(&buf, rss, WithStylesheet(`/feed.xsl`, `text/xsl`)); err == nil {
+		t.Errorf("Expected error for stylesheet")
+	}
+	channel.Title = ``
+	if _, err := NewEncoder(&buf, rss); err == nil {
+		t.Errorf("Expected error for invalid channel")
+	}
+}
+
+func TestEncoderParsedFeed(t *testing.T) {
+	input := `<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:media="http://search.yahoo.com/mrss/">
+  <channel>
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+    <item>
+      <title>Episode 1</title>
+      <enclosure url="https://foo.com/1.mp3" length="42" type="audio/mpeg"/>
+      <dc:creator>Jane Doe</dc:creator>
+      <itunes:episode>1</itunes:episode>
+      <media:thumbnail url="https://foo.com/1.jpg"/>
+    </item>
+  </channel>
+</rss>`
+	decoder := NewDecoder(strings.NewReader(input))
+	header, err := decoder.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	encoder, err := NewEncoder(&buf, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		item, err := decoder.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if err := encoder.EncodeItem(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamed, err := Parse(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to parse streamed feed: %s\n%s", err, buf.String())
+	}
+	if diff := cmp.Diff(expected, streamed); diff != "" {
+		t.Errorf("Streamed feed mismatch (-want +got):\n%s", diff)
+	}
+}
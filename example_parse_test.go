@@ -1,14 +1,14 @@
 package rss2_test
 
 import (
-	"encoding/xml"
 	"fmt"
 
 	"github.com/codesoap/rss2"
 )
 
 // This example shows how you can parse a simple RSS 2.0 feed.
-// The user must ensure that the input is UTF-8 encoded when parsing.
+// Input that is not UTF-8 encoded is converted, if its encoding is
+// supported by rss2.CharsetReader().
 func Example_parseRSS() {
 	input := `
 		<?xml version="1.0" encoding="UTF-8"?>
@@ -24,9 +24,8 @@ func Example_parseRSS() {
 		        </item>
 		    </channel>
 		</rss>`
-	var parse rss2.RSS
-	// Remember to handle any error xml.Unmarshal() gives in your code.
-	xml.Unmarshal([]byte(input), &parse)
+	// Remember to handle any error rss2.Parse() gives in your code.
+	parse, _ := rss2.Parse([]byte(input))
 	fmt.Println(`RSS Version:        `, parse.Version)
 	fmt.Println(`RSS channel's title:`, parse.Channel.Title)
 	fmt.Println(`RSS item's title:   `, parse.Channel.Items[0].Title)
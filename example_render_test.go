@@ -1,8 +1,7 @@
 package rss2_test
 
 import (
-	"encoding/xml"
-	"fmt"
+	"os"
 	"time"
 
 	"github.com/codesoap/rss2"
@@ -13,7 +12,7 @@ import (
 // avoid invalid RSS.
 func Example_renderRSS() {
 	item, _ := rss2.NewItem(`Title of my RSS item`, ``)
-	item.PubDate = &rss2.RSSTime{time.Date(2019, 6, 3, 9, 39, 21, 0, time.UTC)}
+	item.PubDate = &rss2.RSSTime{Time: time.Date(2019, 6, 3, 9, 39, 21, 0, time.UTC)}
 
 	category, _ := rss2.NewCategory(`MSFT`)
 	category.Domain = `http://www.fool.com/cusips`
@@ -23,8 +22,8 @@ func Example_renderRSS() {
 	channel.Categories = []*rss2.Category{category}
 	channel.Items = []*rss2.Item{item}
 
-	// All created RSS elements are structs with XML tags.
-	// Thus xml.Marshal() and the likes can be used.
-	rss, _ := xml.MarshalIndent(rss2.NewRSS(channel), ``, `    `)
-	fmt.Println(xml.Header + string(rss))
+	// Render() validates the feed and writes it with the XML declaration.
+	// All created RSS elements are structs with XML tags, so
+	// xml.Marshal() and the likes can be used as well.
+	rss2.NewRSS(channel).Render(os.Stdout, rss2.WithIndent(``, `    `))
 }
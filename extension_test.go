@@ -0,0 +1,599 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testExtensionRoundTrip parses input, compares the result to expected
+// and checks that rendering it yields input again.
+func testExtensionRoundTrip(t *testing.T, input string, expected *RSS) {
+	t.Helper()
+	parse, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, parse); diff != "" {
+		t.Errorf("RSS parsing mismatch (-want +got):\n%s", diff)
+	}
+	out, err := xml.MarshalIndent(expected, ``, `  `)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(input, string(out)); diff != "" {
+		t.Errorf("RSS rendering mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestContent(t *testing.T) {
+	input := `<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <item>
+      <description>Teaser</description>
+      <content:encoded><![CDATA[<p>Full content with ]]]]><![CDATA[></p>]]></content:encoded>
+    </item>
+  </channel>
+</rss>`
+	item, _ := NewItem(``, `Teaser`)
+	item.Content, _ = NewContent(`<p>Full content with ]]></p>`)
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Items = []*Item{item}
+	testExtensionRoundTrip(t, input, NewRSS(channel))
+
+	// Other prefixes than "content" must be recognized as well.
+	input = `<rss version="2.0" xmlns:c="http://purl.org/rss/1.0/modules/content/">
+		<channel><item><c:encoded>&lt;p&gt;Full&lt;/p&gt;</c:encoded></item></channel>
+	</rss>`
+	var parse RSS
+	if err := xml.Unmarshal([]byte(input), &parse); err != nil {
+		t.Fatal(err)
+	}
+	if content := parse.Channel.Items[0].Content; content == nil || content.Value != `<p>Full</p>` {
+		t.Errorf("Unexpected content %v", content)
+	}
+}
+
+func TestDublinCore(t *testing.T) {
+	input := `<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <dc:rights>CC BY 4.0</dc:rights>
+    <dc:language>en</dc:language>
+    <dc:publisher>Foo Inc.</dc:publisher>
+    <item>
+      <title>Item 1</title>
+      <dc:creator>Jane Doe</dc:creator>
+      <dc:creator>John Doe</dc:creator>
+      <dc:date>2003-06-10T04:00:00+02:00</dc:date>
+      <dc:subject>Space</dc:subject>
+      <dc:identifier>urn:foo:1</dc:identifier>
+    </item>
+  </channel>
+</rss>`
+	item, _ := NewItem(`Item 1`, ``)
+	item.DC = &DublinCore{
+		Creators:   []string{`Jane Doe`, `John Doe`},
+		Date:       &W3CTime{Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.FixedZone(``, 2*60*60))},
+		Subjects:   []string{`Space`},
+		Identifier: `urn:foo:1`,
+	}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.DC = &DublinCore{
+		Rights:    `CC BY 4.0`,
+		Language:  `en`,
+		Publisher: `Foo Inc.`,
+	}
+	channel.Items = []*Item{item}
+	testExtensionRoundTrip(t, input, NewRSS(channel))
+}
+
+func TestParseW3CTime(t *testing.T) {
+	testCases := map[string]W3CTime{
+		"2003-06-10T04:00:00Z":         {Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.UTC)},
+		"2003-06-10T06:00:00.5+02:00":  {Time: time.Date(2003, 6, 10, 4, 0, 0, 500000000, time.UTC)},
+		"2003-06-10T06:00+02:00":       {Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.UTC)},
+		"2003-06-10":                   {Time: time.Date(2003, 6, 10, 0, 0, 0, 0, time.UTC)},
+		"2003-06":                      {Time: time.Date(2003, 6, 1, 0, 0, 0, 0, time.UTC)},
+		"2003":                         {Time: time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC)},
+		" 2003-06-10T04:00:00Z\n\t\t ": {Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.UTC)},
+	}
+	for in, expected := range testCases {
+		if out, err := ParseW3CTime(in); err != nil {
+			t.Errorf("Error parsing '%s': %s", in, err.Error())
+		} else if !out.Time.Equal(expected.Time) {
+			t.Errorf("Parsing '%s' yielded '%s'. Expected '%s'", in, out.Time.String(),
+				expected.Time.String())
+		}
+	}
+	if _, err := ParseW3CTime(`Tue, 10 Jun 2003 04:00:00 GMT`); err == nil {
+		t.Errorf("Expected error for RFC822 time")
+	}
+}
+
+func TestAtomLink(t *testing.T) {
+	input := `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <atom:link href="https://foo.com/feed.xml?page=2" rel="self" type="application/rss+xml"></atom:link>
+    <atom:link href="https://pubsubhubbub.appspot.com/" rel="hub"></atom:link>
+    <atom:link href="https://foo.com/feed.xml?page=3" rel="next"></atom:link>
+    <item>
+      <title>Item 1</title>
+      <atom:link href="https://foo.com/1" hreflang="en" title="Item 1"></atom:link>
+    </item>
+  </channel>
+</rss>`
+	self, _ := NewSelfLink(`https://foo.com/feed.xml?page=2`)
+	hub, _ := NewAtomLink(`https://pubsubhubbub.appspot.com/`, RelHub)
+	next, _ := NewAtomLink(`https://foo.com/feed.xml?page=3`, RelNext)
+	alternate, _ := NewAtomLink(`https://foo.com/1`, ``)
+	alternate.HrefLang = `en`
+	alternate.Title = `Item 1`
+	item, _ := NewItem(`Item 1`, ``)
+	item.AtomLinks = []*AtomLink{alternate}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.AtomLinks = []*AtomLink{self, hub, next}
+	channel.Items = []*Item{item}
+	testExtensionRoundTrip(t, input, NewRSS(channel))
+
+	if link := channel.AtomLink(RelNext); link != next {
+		t.Errorf("Expected next link, got %v", link)
+	}
+	if link := channel.AtomLink(RelPrevious); link != nil {
+		t.Errorf("Expected no previous link, got %v", link)
+	}
+	if link := item.AtomLink(RelAlternate); link != alternate {
+		t.Errorf("Expected alternate link, got %v", link)
+	}
+}
+
+func TestITunes(t *testing.T) {
+	input := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <itunes:author>Jane Doe</itunes:author>
+    <itunes:category text="Society &amp; Culture">
+      <itunes:category text="Documentary"></itunes:category>
+    </itunes:category>
+    <itunes:category text="Technology"></itunes:category>
+    <itunes:explicit>false</itunes:explicit>
+    <itunes:image href="https://foo.com/cover.jpg"></itunes:image>
+    <itunes:owner>
+      <itunes:name>Jane Doe</itunes:name>
+      <itunes:email>jane@foo.com</itunes:email>
+    </itunes:owner>
+    <itunes:type>serial</itunes:type>
+    <item>
+      <title>Episode 1</title>
+      <enclosure url="https://foo.com/1.mp3" length="42" type="audio/mpeg"></enclosure>
+      <itunes:duration>01:02:03</itunes:duration>
+      <itunes:episode>1</itunes:episode>
+      <itunes:episodeType>full</itunes:episodeType>
+      <itunes:season>2</itunes:season>
+    </item>
+  </channel>
+</rss>`
+	item, _ := NewItem(`Episode 1`, ``)
+	item.Enclosure, _ = NewEnclosure(`https://foo.com/1.mp3`, 42, `audio/mpeg`)
+	item.ITunes = &ITunesItem{
+		Duration:    FormatITunesDuration(time.Hour + 2*time.Minute + 3*time.Second),
+		Episode:     1,
+		EpisodeType: `full`,
+		Season:      2,
+	}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.ITunes = &ITunesChannel{
+		Author:   `Jane Doe`,
+		Explicit: `false`,
+		Type:     `serial`,
+	}
+	society, _ := NewITunesCategory(`Society & Culture`, `Documentary`)
+	technology, _ := NewITunesCategory(`Technology`, ``)
+	channel.ITunes.Categories = []*ITunesCategory{society, technology}
+	channel.ITunes.Image, _ = NewITunesImage(`https://foo.com/cover.jpg`)
+	channel.ITunes.Owner, _ = NewITunesOwner(`Jane Doe`, `jane@foo.com`)
+	channel.Items = []*Item{item}
+	rss := NewRSS(channel)
+	testExtensionRoundTrip(t, input, rss)
+	if err := rss.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %s", err.Error())
+	}
+	channel.ITunes.Image.Href = `https://cdn.foo.com/Cover.JPG?v=2&sig=abc`
+	if err := rss.Validate(); err != nil {
+		t.Errorf("Unexpected validation error for image with query: %s", err.Error())
+	}
+
+	channel.ITunes = &ITunesChannel{
+		Categories: []*ITunesCategory{{Text: `Technology`, Subcategories: []*ITunesCategory{{Text: `Gadgets`}}}},
+		Explicit:   `yes`,
+		Image:      &ITunesImage{Href: `https://foo.com/cover.gif?format=.png`},
+	}
+	item.Enclosure.Type = `audio/ogg`
+	item.ITunes = &ITunesItem{Duration: `1:75`, EpisodeType: `teaser`}
+	expected := ValidationErrors{
+		{`channel/itunes:category[0]/itunes:category[0]@text`, `'Gadgets' is not allowed`},
+		{`channel/itunes:explicit`, `'yes' is not one of 'true', 'false'`},
+		{`channel/itunes:image@href`, `must point to a JPEG or PNG image`},
+		{`channel/item[0]/itunes:duration`, `'1:75' is not in seconds, MM:SS or HH:MM:SS`},
+		{`channel/item[0]/itunes:episodeType`, `'teaser' is not one of 'full', 'trailer', 'bonus'`},
+		{`channel/item[0]/enclosure@type`, `'audio/ogg' is not one of 'audio/x-m4a', 'audio/mpeg', 'video/quicktime', 'video/mp4', 'video/x-m4v', 'application/pdf'`},
+	}
+	if diff := cmp.Diff(expected, rss.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseITunesDuration(t *testing.T) {
+	testCases := map[string]time.Duration{
+		`3723`:     3723 * time.Second,
+		`62:03`:    62*time.Minute + 3*time.Second,
+		`01:02:03`: time.Hour + 2*time.Minute + 3*time.Second,
+	}
+	for in, expected := range testCases {
+		if out, err := ParseITunesDuration(in); err != nil {
+			t.Errorf("Error parsing '%s': %s", in, err.Error())
+		} else if out != expected {
+			t.Errorf("Parsing '%s' yielded '%s'. Expected '%s'", in, out, expected)
+		}
+	}
+	for _, in := range []string{``, `1:2:3:4`, `1:60`, `-1`, `1h`} {
+		if _, err := ParseITunesDuration(in); err == nil {
+			t.Errorf("Expected error parsing '%s'", in)
+		}
+	}
+}
+
+func TestPodcast(t *testing.T) {
+	input := `<rss version="2.0" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <podcast:guid>917393e3-1b1e-5cef-ace4-edaa54e1f810</podcast:guid>
+    <podcast:locked owner="jane@foo.com">yes</podcast:locked>
+    <podcast:funding url="https://foo.com/donate">Support the show!</podcast:funding>
+    <podcast:value type="lightning" method="keysend" suggested="0.00000005000">
+      <podcast:valueRecipient name="Jane" type="node" address="02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52" split="90"></podcast:valueRecipient>
+      <podcast:valueRecipient name="Host" type="node" address="03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a" split="10" fee="true"></podcast:valueRecipient>
+    </podcast:value>
+    <item>
+      <title>Episode 1</title>
+      <enclosure url="https://foo.com/1.mp3" length="42" type="audio/mpeg"></enclosure>
+      <podcast:transcript url="https://foo.com/1.vtt" type="text/vtt" language="en"></podcast:transcript>
+      <podcast:chapters url="https://foo.com/1.json" type="application/json+chapters"></podcast:chapters>
+      <podcast:person role="guest" img="https://foo.com/john.jpg" href="https://foo.com/john">John Doe</podcast:person>
+      <podcast:alternateEnclosure type="audio/opus" length="32" bitrate="128000.5" default="true">
+        <podcast:source uri="https://foo.com/1.opus"></podcast:source>
+        <podcast:source uri="ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y"></podcast:source>
+        <podcast:integrity type="sri" value="sha384-ExVqijgYHm15PqQqdXfW95x+Rs6C+d6E/ICxyQOeFevnxNLR/wtJNrNYTjIysUBo"></podcast:integrity>
+      </podcast:alternateEnclosure>
+    </item>
+  </channel>
+</rss>`
+	recipient1, _ := NewPodcastValueRecipient(`node`, `02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52`, 90)
+	recipient1.Name = `Jane`
+	recipient2, _ := NewPodcastValueRecipient(`node`, `03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a`, 10)
+	recipient2.Name = `Host`
+	recipient2.Fee = true
+	value, _ := NewPodcastValue(`lightning`, `keysend`, []*PodcastValueRecipient{recipient1, recipient2})
+	value.Suggested = `0.00000005000`
+	funding, _ := NewPodcastFunding(`https://foo.com/donate`, `Support the show!`)
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Podcast = &PodcastChannel{
+		GUID:    `917393e3-1b1e-5cef-ace4-edaa54e1f810`,
+		Locked:  NewPodcastLocked(true, `jane@foo.com`),
+		Funding: []*PodcastFunding{funding},
+		Value:   value,
+	}
+
+	transcript, _ := NewPodcastTranscript(`https://foo.com/1.vtt`, `text/vtt`)
+	transcript.Language = `en`
+	chapters, _ := NewPodcastChapters(`https://foo.com/1.json`)
+	person, _ := NewPodcastPerson(`John Doe`)
+	person.Role = `guest`
+	person.Href = `https://foo.com/john`
+	person.Img = `https://foo.com/john.jpg`
+	alternate, _ := NewPodcastAlternateEnclosure(`audio/opus`, `https://foo.com/1.opus`)
+	alternate.Length = 32
+	alternate.Bitrate = 128000.5
+	alternate.Default = true
+	alternate.Sources = append(alternate.Sources, &PodcastSource{
+		XMLName: xml.Name{Local: `podcast:source`},
+		URI:     `ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y`,
+	})
+	alternate.Integrity = &PodcastIntegrity{
+		XMLName: xml.Name{Local: `podcast:integrity`},
+		Type:    `sri`,
+		Value:   `sha384-ExVqijgYHm15PqQqdXfW95x+Rs6C+d6E/ICxyQOeFevnxNLR/wtJNrNYTjIysUBo`,
+	}
+	item, _ := NewItem(`Episode 1`, ``)
+	item.Enclosure, _ = NewEnclosure(`https://foo.com/1.mp3`, 42, `audio/mpeg`)
+	item.Podcast = &PodcastItem{
+		Transcripts:         []*PodcastTranscript{transcript},
+		Chapters:            chapters,
+		Persons:             []*PodcastPerson{person},
+		AlternateEnclosures: []*PodcastAlternateEnclosure{alternate},
+	}
+	channel.Items = []*Item{item}
+	rss := NewRSS(channel)
+	testExtensionRoundTrip(t, input, rss)
+	if err := rss.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %s", err.Error())
+	}
+
+	channel.Podcast.Locked.Value = `true`
+	alternate.Sources = nil
+	expected := ValidationErrors{
+		{`channel/podcast:locked`, `'true' is not one of 'yes', 'no'`},
+		{`channel/item[0]/podcast:alternateEnclosure[0]/podcast:source`, `must be present`},
+	}
+	if diff := cmp.Diff(expected, rss.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMedia(t *testing.T) {
+	input := `<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <item>
+      <title>Video 1</title>
+      <media:group>
+        <media:content url="https://foo.com/1-sd.mp4" type="video/mp4" medium="video" bitrate="1200" height="480" width="854"></media:content>
+        <media:content url="https://foo.com/1-hd.mp4" type="video/mp4" medium="video" bitrate="4000" height="1080" width="1920">
+          <media:thumbnail url="https://foo.com/1-hd.jpg" height="1080" width="1920"></media:thumbnail>
+        </media:content>
+      </media:group>
+      <media:title type="plain">The first video</media:title>
+      <media:description type="html">&lt;b&gt;The&lt;/b&gt; first video</media:description>
+      <media:thumbnail url="https://foo.com/1.jpg" height="360" width="640" time="00:00:05"></media:thumbnail>
+      <media:credit role="producer" scheme="urn:ebu">Jane Doe</media:credit>
+    </item>
+  </channel>
+</rss>`
+	sd, _ := NewMediaContent(`https://foo.com/1-sd.mp4`)
+	sd.Type = `video/mp4`
+	sd.Medium = `video`
+	sd.Bitrate = 1200
+	sd.Width, sd.Height = 854, 480
+	hd, _ := NewMediaContent(`https://foo.com/1-hd.mp4`)
+	hd.Type = `video/mp4`
+	hd.Medium = `video`
+	hd.Bitrate = 4000
+	hd.Width, hd.Height = 1920, 1080
+	hdThumbnail, _ := NewMediaThumbnail(`https://foo.com/1-hd.jpg`, 1920, 1080)
+	hd.Thumbnails = []*MediaThumbnail{hdThumbnail}
+	group, _ := NewMediaGroup(sd, hd)
+	title, _ := NewMediaText(`The first video`)
+	description, _ := NewMediaText(`<b>The</b> first video`)
+	description.Type = `html`
+	thumbnail, _ := NewMediaThumbnail(`https://foo.com/1.jpg`, 640, 360)
+	thumbnail.Time = `00:00:05`
+	credit, _ := NewMediaCredit(`Jane Doe`, `producer`)
+	credit.Scheme = `urn:ebu`
+	item, _ := NewItem(`Video 1`, ``)
+	item.Media = &MediaItem{
+		Groups: []*MediaGroup{group},
+		MediaElements: MediaElements{
+			Title:       title,
+			Description: description,
+			Thumbnails:  []*MediaThumbnail{thumbnail},
+			Credits:     []*MediaCredit{credit},
+		},
+	}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Items = []*Item{item}
+	rss := NewRSS(channel)
+	testExtensionRoundTrip(t, input, rss)
+	if err := rss.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %s", err.Error())
+	}
+	if url := item.ThumbnailURL(); url != `https://foo.com/1-hd.jpg` {
+		t.Errorf("Unexpected thumbnail URL '%s'", url)
+	}
+	if url := item.MediaURL(); url != `https://foo.com/1-hd.mp4` {
+		t.Errorf("Unexpected media URL '%s'", url)
+	}
+	sd.IsDefault = true
+	if url := item.MediaURL(); url != `https://foo.com/1-sd.mp4` {
+		t.Errorf("Unexpected default media URL '%s'", url)
+	}
+
+	sd.Medium = `movie`
+	group.Contents = nil
+	expected := ValidationErrors{
+		{`channel/item[0]/media:group[0]/media:content`, `must be present`},
+	}
+	if diff := cmp.Diff(expected, rss.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+	group.Contents = []*MediaContent{sd}
+	expected = ValidationErrors{
+		{`channel/item[0]/media:group[0]/media:content[0]@medium`,
+			`'movie' is not one of 'image', 'audio', 'video', 'document', 'executable'`},
+	}
+	if diff := cmp.Diff(expected, rss.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+
+	plain, _ := NewItem(`Audio 1`, ``)
+	if plain.ThumbnailURL() != `` || plain.MediaURL() != `` {
+		t.Errorf("Unexpected URLs of item without media")
+	}
+	plain.Enclosure, _ = NewEnclosure(`https://foo.com/1.mp3`, 42, `audio/mpeg`)
+	if url := plain.MediaURL(); url != `https://foo.com/1.mp3` {
+		t.Errorf("Unexpected enclosure URL '%s'", url)
+	}
+}
+
+func TestUnknownElements(t *testing.T) {
+	input := `<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:slash="http://purl.org/rss/1.0/modules/slash/" xmlns:georss="http://www.georss.org/georss">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <vendor xmlns="https://foo.com/vendor" id="42">
+      <setting name="color">blue</setting>
+    </vendor>
+    <item georss:featuretypetag="city">
+      <title>Item 1</title>
+      <dc:contributor>Jane Doe</dc:contributor>
+      <slash:comments>7</slash:comments>
+      <georss:point>45.256 -71.92</georss:point>
+    </item>
+  </channel>
+</rss>`
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Extensions = []*Element{{
+		XMLName: xml.Name{Local: `vendor`},
+		Attrs: []xml.Attr{
+			{Name: xml.Name{Local: `xmlns`}, Value: `https://foo.com/vendor`},
+			{Name: xml.Name{Local: `id`}, Value: `42`},
+		},
+		Children: []*Element{{
+			XMLName: xml.Name{Local: `setting`},
+			Attrs:   []xml.Attr{{Name: xml.Name{Local: `name`}, Value: `color`}},
+			Value:   `blue`,
+		}},
+	}}
+	item, _ := NewItem(`Item 1`, ``)
+	contributor, _ := NewElement(`dc:contributor`, `Jane Doe`)
+	comments, _ := NewElement(`slash:comments`, `7`)
+	point, _ := NewElement(`georss:point`, `45.256 -71.92`)
+	item.Extensions = []*Element{contributor, comments, point}
+	item.Attrs = []xml.Attr{{Name: xml.Name{Local: `georss:featuretypetag`}, Value: `city`}}
+	channel.Items = []*Item{item}
+	rss := NewRSS(channel)
+	rss.Attrs = []xml.Attr{
+		{Name: xml.Name{Local: `xmlns:slash`}, Value: `http://purl.org/rss/1.0/modules/slash/`},
+		{Name: xml.Name{Local: `xmlns:georss`}, Value: `http://www.georss.org/georss`},
+	}
+	testExtensionRoundTrip(t, input, rss)
+
+	if el := item.Extension(`slash:comments`); el == nil || el.Value != `7` {
+		t.Errorf("Unexpected slash:comments %v", el)
+	}
+	vendor := channel.Extension(`vendor`)
+	if vendor == nil || vendor.Attr(`id`) != `42` || vendor.Child(`setting`).Value != `blue` {
+		t.Errorf("Unexpected vendor element %v", vendor)
+	}
+}
+
+type testRating struct {
+	Scheme string `xml:"scheme,attr"`
+	Value  int    `xml:",chardata"`
+}
+
+func TestRegisterExtension(t *testing.T) {
+	const namespace = `https://foo.com/rating`
+	if err := RegisterExtension(namespace, `rating`, `stars`, &testRating{}); err != nil {
+		t.Fatal("Failed to register extension:", err)
+	}
+	input := `<rss version="2.0" xmlns:rating="https://foo.com/rating">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <item>
+      <title>Item 1</title>
+      <rating:stars scheme="five">4</rating:stars>
+    </item>
+  </channel>
+</rss>`
+	rating, err := NewExtension(&testRating{Scheme: `five`, Value: 4})
+	if err != nil {
+		t.Fatal("Failed to create extension:", err)
+	}
+	item, _ := NewItem(`Item 1`, ``)
+	item.Extensions = []*Element{rating}
+	channel, _ := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	channel.Items = []*Item{item}
+	testExtensionRoundTrip(t, input, NewRSS(channel))
+
+	// Documents may use other prefixes for the namespace.
+	input = strings.NewReplacer(`rating:`, `r:`, `xmlns:rating`, `xmlns:r`).Replace(input)
+	rss, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal("Failed to parse:", err)
+	}
+	stars := rss.Channel.Items[0].Extension(`rating:stars`)
+	if stars == nil || stars.Object.(*testRating).Value != 4 {
+		t.Errorf("Unexpected rating:stars %v", stars)
+	}
+
+	if err := RegisterExtension(namespace, `rating`, `stars`, 0); err == nil {
+		t.Errorf("Expected error when registering an element twice")
+	}
+	if err := RegisterExtension(namespace, `r`, `votes`, 0); err == nil {
+		t.Errorf("Expected error when registering a second prefix")
+	}
+	if err := RegisterExtension(`https://bar.com`, `rating`, `votes`, 0); err == nil {
+		t.Errorf("Expected error when reusing a prefix")
+	}
+	if _, err := NewExtension(0); err == nil {
+		t.Errorf("Expected error when creating an unregistered extension")
+	}
+}
+
+func TestPrefixCollision(t *testing.T) {
+	input := `<rss version="2.0" xmlns:media="https://foo.com/other" xmlns:m="http://search.yahoo.com/mrss/">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <item>
+      <title>Item 1</title>
+      <media:content url="u"/>
+      <m:title>Media title</m:title>
+    </item>
+  </channel>
+</rss>`
+	rss, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAttrs := []xml.Attr{{Name: xml.Name{Local: `xmlns:media2`}, Value: `https://foo.com/other`}}
+	if diff := cmp.Diff(expectedAttrs, rss.Attrs); diff != "" {
+		t.Errorf("Attrs mismatch (-want +got):\n%s", diff)
+	}
+	item := rss.Channel.Items[0]
+	expectedExtensions := []*Element{{
+		XMLName: xml.Name{Local: `media2:content`},
+		Attrs:   []xml.Attr{{Name: xml.Name{Local: `url`}, Value: `u`}},
+	}}
+	if diff := cmp.Diff(expectedExtensions, item.Extensions); diff != "" {
+		t.Errorf("Extensions mismatch (-want +got):\n%s", diff)
+	}
+	if item.Media == nil || item.Media.Title == nil || len(item.Media.Contents) > 0 {
+		t.Errorf("Unexpected Media %v", item.Media)
+	}
+	out, err := rss.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`xmlns:media="http://search.yahoo.com/mrss/"`,
+		`xmlns:media2="https://foo.com/other"`,
+		`<media2:content url="u"></media2:content>`,
+		`<media:title>Media title</media:title>`,
+	} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("Expected '%s' in rendered RSS:\n%s", s, out)
+		}
+	}
+}
@@ -10,6 +10,34 @@ type GUID struct {
 	XMLName     xml.Name `xml:"guid"`
 	Value       string   `xml:",chardata"`
 	IsPermaLink bool     `xml:"isPermaLink,attr"`
+
+	// implicit is set when a parsed guid has no isPermaLink attribute.
+	implicit bool
+}
+
+// UnmarshalXML unmarshals a GUID and notes whether the isPermaLink
+// attribute was present.
+func (g *GUID) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	type guid GUID
+	if err := decoder.DecodeElement((*guid)(g), &start); err != nil {
+		return err
+	}
+	g.implicit = true
+	for _, attr := range start.Attr {
+		if attr.Name.Local == `isPermaLink` {
+			g.implicit = false
+		}
+	}
+	return nil
+}
+
+// Equal reports whether g and o have the same name, value and
+// IsPermaLink.
+func (g *GUID) Equal(o *GUID) bool {
+	if g == nil || o == nil {
+		return g == o
+	}
+	return g.XMLName == o.XMLName && g.Value == o.Value && g.IsPermaLink == o.IsPermaLink
 }
 
 // NewGUID creates a new GUID element.
@@ -22,3 +50,8 @@ func NewGUID(value string) (*GUID, error) {
 		Value:   value,
 	}, nil
 }
+
+func (g *GUID) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path, g.Value)
+	return
+}
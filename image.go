@@ -29,3 +29,16 @@ func NewImage(url, title, link string) (*Image, error) {
 		Link:    link,
 	}, nil
 }
+
+func (i *Image) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`/url`, i.URL)
+	errs.requireNonEmpty(path+`/title`, i.Title)
+	errs.requireNonEmpty(path+`/link`, i.Link)
+	if i.Width < 0 || i.Width > 144 {
+		errs.add(path+`/width`, `%d not between 0 and 144`, i.Width)
+	}
+	if i.Height < 0 || i.Height > 400 {
+		errs.add(path+`/height`, `%d not between 0 and 400`, i.Height)
+	}
+	return
+}
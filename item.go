@@ -6,21 +6,49 @@ import (
 )
 
 // Item represents an rss item. At least Title or Description must be
-// present.
+// present. Content is an extension for the full content of the item,
+// while Description often only holds a teaser. AtomLinks, DC, ITunes,
+// Podcast and Media are extensions as well. The elements of DC, ITunes,
+// Podcast and Media are rendered as children of the item. Elements and
+// attributes unknown to this package are kept in Extensions and Attrs.
 type Item struct {
-	XMLName     xml.Name    `xml:"item"`
-	Title       string      `xml:"title,omitempty"`
-	Link        string      `xml:"link,omitempty"`
-	Description string      `xml:"description,omitempty"`
-	Author      string      `xml:"author,omitempty"`
-	Categories  []*Category `xml:"category,omitempty"`
-	Comments    string      `xml:"comments,omitempty"`
-	Enclosure   *Enclosure  `xml:"enclosure,omitempty"`
-	GUID        *GUID       `xml:"guid,omitempty"`
-	PubDate     *RSSTime    `xml:"pubDate,omitempty"`
-	Source      *Source     `xml:"source,omitempty"`
+	XMLName     xml.Name     `xml:"item"`
+	Title       string       `xml:"title,omitempty"`
+	Link        string       `xml:"link,omitempty"`
+	Description string       `xml:"description,omitempty"`
+	Author      string       `xml:"author,omitempty"`
+	Categories  []*Category  `xml:"category,omitempty"`
+	Comments    string       `xml:"comments,omitempty"`
+	Enclosure   *Enclosure   `xml:"enclosure,omitempty"`
+	GUID        *GUID        `xml:"guid,omitempty"`
+	PubDate     *RSSTime     `xml:"pubDate,omitempty"`
+	Source      *Source      `xml:"source,omitempty"`
+	Content     *Content     `xml:"content:encoded,omitempty"`
+	AtomLinks   []*AtomLink  `xml:"atom:link,omitempty"`
+	DC          *DublinCore  `xml:"-"`
+	ITunes      *ITunesItem  `xml:"-"`
+	Podcast     *PodcastItem `xml:"-"`
+	Media       *MediaItem   `xml:"-"`
+	Extensions  []*Element   `xml:",any"`
+	Attrs       []xml.Attr   `xml:",any,attr"`
 }
 
+// itemElements is marshalled and unmarshalled in place of an Item, so
+// that the fields of its extensions become elements of the item.
+// Extensions shadow those of the item to keep them last.
+type itemElements struct {
+	XMLName xml.Name `xml:"item"`
+	plainItem
+	*DublinCore
+	*ITunesItem
+	*PodcastItem
+	*MediaItem
+	Extensions []*Element `xml:",any"`
+}
+
+// plainItem has the fields, but not the methods of Item.
+type plainItem Item
+
 // NewItem creates a new Item. Either title or description may be empty.
 func NewItem(title, description string) (*Item, error) {
 	if len(title) == 0 && len(description) == 0 {
@@ -32,3 +60,112 @@ func NewItem(title, description string) (*Item, error) {
 		Description: description,
 	}, nil
 }
+
+// MarshalXML marshals an item element.
+func (it Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(it.elements())
+}
+
+// UnmarshalXML unmarshals an item element.
+func (it *Item) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	elements := it.elements()
+	err := decoder.DecodeElement(elements, &start)
+	*it = Item(elements.plainItem)
+	it.XMLName, it.DC = elements.XMLName, elements.DublinCore
+	it.ITunes, it.Podcast = elements.ITunesItem, elements.PodcastItem
+	it.Media = elements.MediaItem
+	it.Extensions = elements.Extensions
+	return err
+}
+
+func (it *Item) elements() *itemElements {
+	return &itemElements{
+		plainItem:   plainItem(*it),
+		DublinCore:  it.DC,
+		ITunesItem:  it.ITunes,
+		PodcastItem: it.Podcast,
+		MediaItem:   it.Media,
+		Extensions:  it.Extensions,
+	}
+}
+
+func (it *Item) validate(path string) (errs ValidationErrors) {
+	if len(it.Title) == 0 && len(it.Description) == 0 {
+		errs.add(path, `title or description must be present`)
+	}
+	for i, category := range it.Categories {
+		errs = append(errs, category.validate(indexPath(path, `category`, i))...)
+	}
+	if it.Enclosure != nil {
+		errs = append(errs, it.Enclosure.validate(path+`/enclosure`)...)
+	}
+	if it.GUID != nil {
+		errs = append(errs, it.GUID.validate(path+`/guid`)...)
+	}
+	if it.PubDate != nil {
+		errs = append(errs, it.PubDate.validate(path+`/pubDate`)...)
+	}
+	if it.Source != nil {
+		errs = append(errs, it.Source.validate(path+`/source`)...)
+	}
+	if it.Content != nil {
+		errs = append(errs, it.Content.validate(path+`/content:encoded`)...)
+	}
+	for i, link := range it.AtomLinks {
+		errs = append(errs, link.validate(indexPath(path, `atom:link`, i))...)
+	}
+	if it.DC != nil {
+		errs = append(errs, it.DC.validate(path)...)
+	}
+	if it.ITunes != nil {
+		errs = append(errs, it.ITunes.validate(path)...)
+		errs = append(errs, validateITunesEnclosure(path, it.Enclosure)...)
+	}
+	if it.Podcast != nil {
+		errs = append(errs, it.Podcast.validate(path)...)
+	}
+	if it.Media != nil {
+		errs = append(errs, it.Media.validate(path)...)
+	}
+	return
+}
+
+// namespaces adds the namespaces of the extensions used by it to used.
+func (it *Item) namespaces(used map[string]bool) {
+	if it.Content != nil {
+		used[ContentNamespace] = true
+	}
+	if len(it.AtomLinks) > 0 {
+		used[AtomNamespace] = true
+	}
+	if it.DC != nil {
+		used[DublinCoreNamespace] = true
+	}
+	if it.ITunes != nil {
+		used[ITunesNamespace] = true
+	}
+	if it.Podcast != nil {
+		used[PodcastNamespace] = true
+	}
+	if it.Media != nil {
+		used[MediaNamespace] = true
+	}
+	for _, el := range it.Extensions {
+		el.namespaces(used)
+	}
+	for _, attr := range it.Attrs {
+		markPrefixUsed(used, attr.Name.Local)
+	}
+}
+
+// AtomLink returns the first of the item's AtomLinks with the given rel or
+// nil, if there is none.
+func (it *Item) AtomLink(rel string) *AtomLink {
+	return findAtomLink(it.AtomLinks, rel)
+}
+
+// Extension returns the first of the item's Extensions with the given
+// name or nil, if there is none.
+func (it *Item) Extension(name string) *Element {
+	return findElement(it.Extensions, name)
+}
@@ -0,0 +1,273 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// iTunesCategories contains the categories and subcategories accepted
+// by Apple Podcasts.
+var iTunesCategories = map[string][]string{
+	`Arts`:                    {`Books`, `Design`, `Fashion & Beauty`, `Food`, `Performing Arts`, `Visual Arts`},
+	`Business`:                {`Careers`, `Entrepreneurship`, `Investing`, `Management`, `Marketing`, `Non-Profit`},
+	`Comedy`:                  {`Comedy Interviews`, `Improv`, `Stand-Up`},
+	`Education`:               {`Courses`, `How To`, `Language Learning`, `Self-Improvement`},
+	`Fiction`:                 {`Comedy Fiction`, `Drama`, `Science Fiction`},
+	`Government`:              {},
+	`History`:                 {},
+	`Health & Fitness`:        {`Alternative Health`, `Fitness`, `Medicine`, `Mental Health`, `Nutrition`, `Sexuality`},
+	`Kids & Family`:           {`Education for Kids`, `Parenting`, `Pets & Animals`, `Stories for Kids`},
+	`Leisure`:                 {`Animation & Manga`, `Automotive`, `Aviation`, `Crafts`, `Games`, `Hobbies`, `Home & Garden`, `Video Games`},
+	`Music`:                   {`Music Commentary`, `Music History`, `Music Interviews`},
+	`News`:                    {`Business News`, `Daily News`, `Entertainment News`, `News Commentary`, `Politics`, `Sports News`, `Tech News`},
+	`Religion & Spirituality`: {`Buddhism`, `Christianity`, `Hinduism`, `Islam`, `Judaism`, `Religion`, `Spirituality`},
+	`Science`:                 {`Astronomy`, `Chemistry`, `Earth Sciences`, `Life Sciences`, `Mathematics`, `Natural Sciences`, `Nature`, `Physics`, `Social Sciences`},
+	`Society & Culture`:       {`Documentary`, `Personal Journals`, `Philosophy`, `Places & Travel`, `Relationships`},
+	`Sports`:                  {`Baseball`, `Basketball`, `Cricket`, `Fantasy Sports`, `Football`, `Golf`, `Hockey`, `Rugby`, `Running`, `Soccer`, `Swimming`, `Tennis`, `Volleyball`, `Wilderness`, `Wrestling`},
+	`Technology`:              {},
+	`True Crime`:              {},
+	`TV & Film`:               {`After Shows`, `Film History`, `Film Interviews`, `Film Reviews`, `TV Reviews`},
+}
+
+// iTunesMediaTypes contains the enclosure types accepted by Apple
+// Podcasts.
+var iTunesMediaTypes = []string{
+	`audio/x-m4a`, `audio/mpeg`, `video/quicktime`, `video/mp4`,
+	`video/x-m4v`, `application/pdf`,
+}
+
+// ITunesChannel holds the elements of Apple's podcast extension for
+// channels. Image, Categories and Explicit are required by Apple.
+// Explicit must be "true" or "false", Type must be "episodic" or
+// "serial", Block and Complete must be "Yes", if present.
+type ITunesChannel struct {
+	Author     string            `xml:"itunes:author,omitempty"`
+	Block      string            `xml:"itunes:block,omitempty"`
+	Categories []*ITunesCategory `xml:"itunes:category,omitempty"`
+	Complete   string            `xml:"itunes:complete,omitempty"`
+	Explicit   string            `xml:"itunes:explicit,omitempty"`
+	Image      *ITunesImage      `xml:"itunes:image,omitempty"`
+	Keywords   string            `xml:"itunes:keywords,omitempty"`
+	NewFeedURL string            `xml:"itunes:new-feed-url,omitempty"`
+	Owner      *ITunesOwner      `xml:"itunes:owner,omitempty"`
+	Subtitle   string            `xml:"itunes:subtitle,omitempty"`
+	Summary    string            `xml:"itunes:summary,omitempty"`
+	Title      string            `xml:"itunes:title,omitempty"`
+	Type       string            `xml:"itunes:type,omitempty"`
+}
+
+// ITunesItem holds the elements of Apple's podcast extension for items.
+// The media file itself is the Item's Enclosure, which Apple requires.
+// Duration must be given in seconds or as "HH:MM:SS" or "MM:SS", see
+// FormatITunesDuration. EpisodeType must be "full", "trailer" or
+// "bonus", Explicit "true" or "false" and Block "Yes", if present.
+type ITunesItem struct {
+	Author      string       `xml:"itunes:author,omitempty"`
+	Block       string       `xml:"itunes:block,omitempty"`
+	Duration    string       `xml:"itunes:duration,omitempty"`
+	Episode     int          `xml:"itunes:episode,omitempty"`
+	EpisodeType string       `xml:"itunes:episodeType,omitempty"`
+	Explicit    string       `xml:"itunes:explicit,omitempty"`
+	Image       *ITunesImage `xml:"itunes:image,omitempty"`
+	Season      int          `xml:"itunes:season,omitempty"`
+	Subtitle    string       `xml:"itunes:subtitle,omitempty"`
+	Summary     string       `xml:"itunes:summary,omitempty"`
+	Title       string       `xml:"itunes:title,omitempty"`
+}
+
+// ITunesCategory represents an itunes:category element. Text must be
+// one of the categories defined by Apple. Subcategories may contain at
+// most one subcategory of Text.
+type ITunesCategory struct {
+	XMLName       xml.Name          `xml:"itunes:category"`
+	Text          string            `xml:"text,attr"`
+	Subcategories []*ITunesCategory `xml:"itunes:category,omitempty"`
+}
+
+// ITunesImage represents an itunes:image element. Href must point to a
+// JPEG or PNG image.
+type ITunesImage struct {
+	XMLName xml.Name `xml:"itunes:image"`
+	Href    string   `xml:"href,attr"`
+}
+
+// ITunesOwner represents an itunes:owner element. Email must be
+// present.
+type ITunesOwner struct {
+	XMLName xml.Name `xml:"itunes:owner"`
+	Name    string   `xml:"itunes:name,omitempty"`
+	Email   string   `xml:"itunes:email"`
+}
+
+// NewITunesCategory creates a new ITunesCategory element. subcategory
+// may be empty.
+func NewITunesCategory(text, subcategory string) (*ITunesCategory, error) {
+	if len(text) == 0 {
+		return nil, fmt.Errorf(`empty text passed to NewITunesCategory()`)
+	}
+	category := &ITunesCategory{
+		XMLName: xml.Name{Local: `itunes:category`},
+		Text:    text,
+	}
+	if len(subcategory) > 0 {
+		category.Subcategories = []*ITunesCategory{{
+			XMLName: xml.Name{Local: `itunes:category`},
+			Text:    subcategory,
+		}}
+	}
+	return category, nil
+}
+
+// NewITunesImage creates a new ITunesImage element.
+func NewITunesImage(href string) (*ITunesImage, error) {
+	if len(href) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewITunesImage()`)
+	}
+	return &ITunesImage{
+		XMLName: xml.Name{Local: `itunes:image`},
+		Href:    href,
+	}, nil
+}
+
+// NewITunesOwner creates a new ITunesOwner element. name may be empty.
+func NewITunesOwner(name, email string) (*ITunesOwner, error) {
+	if len(email) == 0 {
+		return nil, fmt.Errorf(`empty email passed to NewITunesOwner()`)
+	}
+	return &ITunesOwner{
+		XMLName: xml.Name{Local: `itunes:owner`},
+		Name:    name,
+		Email:   email,
+	}, nil
+}
+
+// FormatITunesDuration formats d as "HH:MM:SS" for
+// ITunesItem.Duration.
+func FormatITunesDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf(`%02d:%02d:%02d`, seconds/3600, seconds/60%60, seconds%60)
+}
+
+// ParseITunesDuration parses the formats allowed in
+// ITunesItem.Duration: seconds, "MM:SS" and "HH:MM:SS".
+func ParseITunesDuration(in string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(in), `:`)
+	if len(parts) > 3 {
+		return 0, fmt.Errorf(`invalid duration '%s'`, in)
+	}
+	var seconds int
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 || i > 0 && value > 59 {
+			return 0, fmt.Errorf(`invalid duration '%s'`, in)
+		}
+		seconds = seconds*60 + value
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (i *ITunesChannel) validate(path string) (errs ValidationErrors) {
+	if len(i.Categories) == 0 {
+		errs.add(path+`/itunes:category`, `must be present`)
+	}
+	for j, category := range i.Categories {
+		errs = append(errs, category.validate(indexPath(path, `itunes:category`, j))...)
+	}
+	requireYes(&errs, path+`/itunes:block`, i.Block)
+	requireYes(&errs, path+`/itunes:complete`, i.Complete)
+	if len(i.Explicit) == 0 {
+		errs.add(path+`/itunes:explicit`, `must be present`)
+	} else {
+		errs.requireOneOf(path+`/itunes:explicit`, i.Explicit, `true`, `false`)
+	}
+	if i.Image == nil {
+		errs.add(path+`/itunes:image`, `must be present`)
+	} else {
+		errs = append(errs, i.Image.validate(path+`/itunes:image`)...)
+	}
+	if i.Owner != nil {
+		errs.requireNonEmpty(path+`/itunes:owner/itunes:email`, i.Owner.Email)
+	}
+	if len(i.Type) > 0 {
+		errs.requireOneOf(path+`/itunes:type`, i.Type, `episodic`, `serial`)
+	}
+	return
+}
+
+func (i *ITunesItem) validate(path string) (errs ValidationErrors) {
+	requireYes(&errs, path+`/itunes:block`, i.Block)
+	if _, err := ParseITunesDuration(i.Duration); len(i.Duration) > 0 && err != nil {
+		errs.add(path+`/itunes:duration`, `'%s' is not in seconds, MM:SS or HH:MM:SS`, i.Duration)
+	}
+	if i.Episode < 0 {
+		errs.add(path+`/itunes:episode`, `must not be negative`)
+	}
+	if len(i.EpisodeType) > 0 {
+		errs.requireOneOf(path+`/itunes:episodeType`, i.EpisodeType, `full`, `trailer`, `bonus`)
+	}
+	if len(i.Explicit) > 0 {
+		errs.requireOneOf(path+`/itunes:explicit`, i.Explicit, `true`, `false`)
+	}
+	if i.Image != nil {
+		errs = append(errs, i.Image.validate(path+`/itunes:image`)...)
+	}
+	if i.Season < 0 {
+		errs.add(path+`/itunes:season`, `must not be negative`)
+	}
+	return
+}
+
+func (c *ITunesCategory) validate(path string) (errs ValidationErrors) {
+	subcategories, ok := iTunesCategories[c.Text]
+	if !ok {
+		errs.add(path+`@text`, `'%s' is not an Apple Podcasts category`, c.Text)
+		return
+	}
+	if len(c.Subcategories) > 1 {
+		errs.add(path, `must not contain more than one subcategory`)
+	}
+	for i, subcategory := range c.Subcategories {
+		errs.requireOneOf(indexPath(path, `itunes:category`, i)+`@text`,
+			subcategory.Text, subcategories...)
+	}
+	return
+}
+
+func (i *ITunesImage) validate(path string) (errs ValidationErrors) {
+	if len(i.Href) == 0 {
+		errs.add(path+`@href`, `must not be empty`)
+		return
+	}
+	// Query strings, e.g. of versioned or signed URLs, are allowed.
+	u, err := url.Parse(strings.TrimSpace(i.Href))
+	if err != nil {
+		errs.add(path+`@href`, `'%s' is not a valid URL`, i.Href)
+		return
+	}
+	if p := strings.ToLower(u.Path); !strings.HasSuffix(p, `.jpg`) &&
+		!strings.HasSuffix(p, `.jpeg`) && !strings.HasSuffix(p, `.png`) {
+		errs.add(path+`@href`, `must point to a JPEG or PNG image`)
+	}
+	return
+}
+
+// validateITunesEnclosure checks that an episode contains a media file
+// accepted by Apple.
+func validateITunesEnclosure(path string, enclosure *Enclosure) (errs ValidationErrors) {
+	if enclosure == nil {
+		errs.add(path+`/enclosure`, `must be present for podcast episodes`)
+	} else if len(enclosure.Type) > 0 {
+		errs.requireOneOf(path+`/enclosure@type`, enclosure.Type, iTunesMediaTypes...)
+	}
+	return
+}
+
+func requireYes(errs *ValidationErrors, path, value string) {
+	if len(value) > 0 {
+		errs.requireOneOf(path, value, `Yes`)
+	}
+}
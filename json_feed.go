@@ -0,0 +1,691 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONFeedVersion is the version of the JSON Feed documents written by
+// RenderJSONFeed.
+const JSONFeedVersion = `https://jsonfeed.org/version/1.1`
+
+// jsonFeed represents a JSON Feed document. RSS fields, that JSON Feed
+// lacks, are kept in the _rss extension.
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url,omitempty"`
+	Description string          `json:"description,omitempty"`
+	NextURL     string          `json:"next_url,omitempty"`
+	Icon        string          `json:"icon,omitempty"`
+	Authors     []*jsonAuthor   `json:"authors,omitempty"`
+	Author      *jsonAuthor     `json:"author,omitempty"`
+	Language    string          `json:"language,omitempty"`
+	Hubs        []*jsonHub      `json:"hubs,omitempty"`
+	Items       []*jsonItem     `json:"items"`
+	RSS         *jsonRSSChannel `json:"_rss,omitempty"`
+}
+
+type jsonItem struct {
+	ID            string            `json:"id"`
+	URL           string            `json:"url,omitempty"`
+	ExternalURL   string            `json:"external_url,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	ContentHTML   string            `json:"content_html,omitempty"`
+	ContentText   *string           `json:"content_text,omitempty"`
+	Summary       string            `json:"summary,omitempty"`
+	Image         string            `json:"image,omitempty"`
+	DatePublished string            `json:"date_published,omitempty"`
+	DateModified  string            `json:"date_modified,omitempty"`
+	Authors       []*jsonAuthor     `json:"authors,omitempty"`
+	Author        *jsonAuthor       `json:"author,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Attachments   []*jsonAttachment `json:"attachments,omitempty"`
+	RSS           *jsonRSSItem      `json:"_rss,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type jsonHub struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type jsonAttachment struct {
+	URL               string  `json:"url"`
+	MIMEType          string  `json:"mime_type"`
+	Title             string  `json:"title,omitempty"`
+	SizeInBytes       int     `json:"size_in_bytes,omitempty"`
+	DurationInSeconds float64 `json:"duration_in_seconds,omitempty"`
+}
+
+// jsonRSSChannel is the _rss extension of a feed.
+type jsonRSSChannel struct {
+	Copyright     string          `json:"copyright,omitempty"`
+	WebMaster     string          `json:"webMaster,omitempty"`
+	PubDate       string          `json:"pubDate,omitempty"`
+	LastBuildDate string          `json:"lastBuildDate,omitempty"`
+	Categories    []*jsonCategory `json:"categories,omitempty"`
+	Generator     string          `json:"generator,omitempty"`
+	Docs          string          `json:"docs,omitempty"`
+	Cloud         *jsonCloud      `json:"cloud,omitempty"`
+	TTL           int             `json:"ttl,omitempty"`
+	Image         *jsonImage      `json:"image,omitempty"`
+	Rating        string          `json:"rating,omitempty"`
+	TextInput     *jsonTextInput  `json:"textInput,omitempty"`
+	SkipHours     []int           `json:"skipHours,omitempty"`
+	SkipDays      []string        `json:"skipDays,omitempty"`
+}
+
+// jsonRSSItem is the _rss extension of an item.
+type jsonRSSItem struct {
+	IsPermaLink bool            `json:"isPermaLink,omitempty"`
+	Comments    string          `json:"comments,omitempty"`
+	Categories  []*jsonCategory `json:"categories,omitempty"`
+	Source      *jsonSource     `json:"source,omitempty"`
+}
+
+// The following types have the fields of the corresponding elements,
+// so that they can be converted.
+
+type jsonCategory struct {
+	XMLName xml.Name `json:"-"`
+	Value   string   `json:"value"`
+	Domain  string   `json:"domain,omitempty"`
+}
+
+type jsonCloud struct {
+	XMLName           xml.Name `json:"-"`
+	Domain            string   `json:"domain"`
+	Port              int      `json:"port"`
+	Path              string   `json:"path"`
+	RegisterProcedure string   `json:"registerProcedure"`
+	Protocol          string   `json:"protocol"`
+}
+
+type jsonImage struct {
+	XMLName     xml.Name `json:"-"`
+	URL         string   `json:"url"`
+	Title       string   `json:"title"`
+	Link        string   `json:"link"`
+	Width       int      `json:"width,omitempty"`
+	Height      int      `json:"height,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+type jsonTextInput struct {
+	XMLName     xml.Name `json:"-"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Name        string   `json:"name"`
+	Link        string   `json:"link"`
+}
+
+type jsonSource struct {
+	XMLName xml.Name `json:"-"`
+	Value   string   `json:"value"`
+	URL     string   `json:"url"`
+}
+
+// RenderJSONFeed writes ch as a JSON Feed 1.1 document to w. Of the
+// RenderOptions, only WithIndent has an effect. The fields of the
+// channel are mapped as follows:
+//   - Title, Link, Description and Language to title, home_page_url,
+//     description and language
+//   - AtomLinks with the Rel "self", "next" and "hub" to feed_url,
+//     next_url and hubs
+//   - the URL of Image to icon
+//   - ManagingEditor and the DublinCore Creators to authors
+//
+// The fields of items are mapped as follows:
+//   - GUID or else Link to id; see RenderAtom for the fallback
+//   - Link, Title to url and title
+//   - the first AtomLink with the Rel "related" to external_url
+//   - Content to content_html and Description to summary or, if there
+//     is no Content, Description to content_html; items with neither
+//     get an empty content_text, since JSON Feed requires content
+//   - Item.ThumbnailURL to image
+//   - PubDate to date_published
+//   - Author and the DublinCore Creators to authors
+//   - the values of Categories to tags
+//   - Enclosure to attachments
+//
+// Email addresses of authors are given as mailto URLs. All other RSS
+// fields of channels and items are stored in the "_rss" extension
+// objects of the feed and items. Fields without an equivalent, e.g. of
+// the iTunes extension, are left out and reported as Warnings of
+// *UnrepresentableError. The document is complete in that case.
+func (ch *Channel) RenderJSONFeed(w io.Writer, opts ...RenderOption) error {
+	var config renderConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	var warnings Warnings
+	feed := ch.jsonFeed(func(path string) {
+		warnings = append(warnings, &UnrepresentableError{Path: path, Format: `JSON Feed`})
+	})
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent(config.prefix, config.indent)
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	if len(warnings) > 0 {
+		return warnings
+	}
+	return nil
+}
+
+// JSONFeedBytes returns the document written by RenderJSONFeed. Warnings
+// are returned together with the document.
+func (ch *Channel) JSONFeedBytes(opts ...RenderOption) ([]byte, error) {
+	var buf bytes.Buffer
+	err := ch.RenderJSONFeed(&buf, opts...)
+	var warnings Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		return nil, err
+	}
+	return buf.Bytes(), err
+}
+
+func (ch *Channel) jsonFeed(unrepresentable func(path string)) *jsonFeed {
+	const path = `channel`
+	feed := &jsonFeed{
+		Version:     JSONFeedVersion,
+		Title:       ch.Title,
+		HomePageURL: ch.Link,
+		Description: ch.Description,
+		Language:    ch.Language,
+		Items:       []*jsonItem{},
+		RSS: &jsonRSSChannel{
+			Copyright:     ch.Copyright,
+			WebMaster:     ch.WebMaster,
+			PubDate:       formatJSONTime(ch.PubDate),
+			LastBuildDate: formatJSONTime(ch.LastBuildDate),
+			Generator:     ch.Generator,
+			Docs:          ch.Docs,
+			TTL:           ch.TTL,
+			Rating:        ch.Rating,
+		},
+	}
+	for i, link := range ch.AtomLinks {
+		switch {
+		case link.Rel == RelSelf && len(feed.FeedURL) == 0:
+			feed.FeedURL = link.Href
+		case link.Rel == RelNext && len(feed.NextURL) == 0:
+			feed.NextURL = link.Href
+		case link.Rel == RelHub:
+			feed.Hubs = append(feed.Hubs, &jsonHub{Type: `WebSub`, URL: link.Href})
+		default:
+			unrepresentable(indexPath(path, `atom:link`, i))
+		}
+	}
+	if len(ch.ManagingEditor) > 0 {
+		feed.Authors = append(feed.Authors, jsonAuthorOf(ch.ManagingEditor))
+	}
+	for _, category := range ch.Categories {
+		c := jsonCategory(*category)
+		feed.RSS.Categories = append(feed.RSS.Categories, &c)
+	}
+	if ch.Cloud != nil {
+		cloud := jsonCloud(*ch.Cloud)
+		feed.RSS.Cloud = &cloud
+	}
+	if ch.Image != nil {
+		feed.Icon = ch.Image.URL
+		image := jsonImage(*ch.Image)
+		feed.RSS.Image = &image
+	}
+	if ch.TextInput != nil {
+		textInput := jsonTextInput(*ch.TextInput)
+		feed.RSS.TextInput = &textInput
+	}
+	if ch.SkipHours != nil {
+		feed.RSS.SkipHours = ch.SkipHours.Hours
+	}
+	if ch.SkipDays != nil {
+		feed.RSS.SkipDays = ch.SkipDays.Days
+	}
+	if ch.DC != nil {
+		dc := *ch.DC
+		for _, creator := range dc.Creators {
+			feed.Authors = append(feed.Authors, &jsonAuthor{Name: creator})
+		}
+		dc.Creators = nil
+		dc.unrepresentable(path, unrepresentable)
+	}
+	if ch.ITunes != nil {
+		unrepresentable(path + `/itunes:*`)
+	}
+	if ch.Podcast != nil {
+		unrepresentable(path + `/podcast:*`)
+	}
+	unrepresentableExtensions(path, ch.Extensions, ch.Attrs, unrepresentable)
+	if reflect.ValueOf(*feed.RSS).IsZero() {
+		feed.RSS = nil
+	}
+	for i, item := range ch.Items {
+		feed.Items = append(feed.Items,
+			item.jsonItem(feed.HomePageURL, indexPath(path, `item`, i), unrepresentable))
+	}
+	return feed
+}
+
+func (it *Item) jsonItem(feedID, path string, unrepresentable func(path string)) *jsonItem {
+	item := &jsonItem{
+		URL:           it.Link,
+		Title:         it.Title,
+		ContentHTML:   it.Description,
+		Image:         it.ThumbnailURL(),
+		DatePublished: formatJSONTime(it.PubDate),
+		RSS:           &jsonRSSItem{Comments: it.Comments},
+	}
+	if it.Content != nil {
+		item.ContentHTML, item.Summary = it.Content.Value, it.Description
+	}
+	if len(item.ContentHTML) == 0 {
+		// JSON Feed requires content_html or content_text.
+		item.ContentText = new(string)
+	}
+	switch {
+	case it.GUID != nil:
+		item.ID = it.GUID.Value
+		item.RSS.IsPermaLink = it.GUID.IsPermaLink
+	case len(it.Link) > 0:
+		item.ID = it.Link
+	default:
+		item.ID = uuidURN(feedID + `#` + it.Title + "\n" + it.Description)
+	}
+	for i, link := range it.AtomLinks {
+		if link.Rel == `related` && len(item.ExternalURL) == 0 {
+			item.ExternalURL = link.Href
+		} else {
+			unrepresentable(indexPath(path, `atom:link`, i))
+		}
+	}
+	if len(it.Author) > 0 {
+		item.Authors = append(item.Authors, jsonAuthorOf(it.Author))
+	}
+	withDomain := false
+	for _, category := range it.Categories {
+		item.Tags = append(item.Tags, category.Value)
+		withDomain = withDomain || len(category.Domain) > 0
+	}
+	if withDomain {
+		for _, category := range it.Categories {
+			c := jsonCategory(*category)
+			item.RSS.Categories = append(item.RSS.Categories, &c)
+		}
+	}
+	if it.Enclosure != nil {
+		item.Attachments = []*jsonAttachment{{
+			URL:         it.Enclosure.URL,
+			MIMEType:    it.Enclosure.Type,
+			SizeInBytes: it.Enclosure.Length,
+		}}
+	}
+	if it.Source != nil {
+		source := jsonSource(*it.Source)
+		item.RSS.Source = &source
+	}
+	if it.DC != nil {
+		dc := *it.DC
+		for _, creator := range dc.Creators {
+			item.Authors = append(item.Authors, &jsonAuthor{Name: creator})
+		}
+		dc.Creators = nil
+		dc.unrepresentable(path, unrepresentable)
+	}
+	for _, field := range []struct {
+		element string
+		present bool
+	}{
+		{`itunes:*`, it.ITunes != nil},
+		{`podcast:*`, it.Podcast != nil},
+		{`media:*`, it.Media != nil},
+	} {
+		if field.present {
+			unrepresentable(path + `/` + field.element)
+		}
+	}
+	unrepresentableExtensions(path, it.Extensions, it.Attrs, unrepresentable)
+	if item.RSS.Comments == `` && !item.RSS.IsPermaLink &&
+		item.RSS.Categories == nil && item.RSS.Source == nil {
+		item.RSS = nil
+	}
+	return item
+}
+
+// ParseJSONFeed parses a JSON Feed document of version 1 or 1.1 and
+// converts it to an RSS. The mapping is the reverse of the one of
+// Channel.RenderJSONFeed. Additionally, content_text is used as
+// Description, if there is no content_html, and date_modified is used
+// as PubDate, if there is no date_published. Of several authors, the
+// first is used as Author or ManagingEditor and the others as
+// DublinCore Creators. If an item has an image, it is stored as the
+// thumbnail of a MediaItem.
+//
+// Attachments beyond the first are left out and reported as Warnings of
+// *UnrepresentableError. The returned RSS is complete in that case.
+func ParseJSONFeed(data []byte) (*RSS, error) {
+	return ParseOptions{}.ParseJSONFeed(data)
+}
+
+// ParseJSONFeedFile parses the JSON Feed document stored at path. See
+// ParseJSONFeed.
+func ParseJSONFeedFile(path string) (*RSS, error) {
+	return ParseOptions{}.ParseJSONFeedFile(path)
+}
+
+// ParseJSONFeedReader parses a JSON Feed document read from r. See
+// ParseJSONFeed.
+func ParseJSONFeedReader(r io.Reader) (*RSS, error) {
+	return ParseOptions{}.ParseJSONFeedReader(r)
+}
+
+// ParseJSONFeed is like the package level ParseJSONFeed, but respects o.
+func (o ParseOptions) ParseJSONFeed(data []byte) (*RSS, error) {
+	return o.ParseJSONFeedReader(bytes.NewReader(data))
+}
+
+// ParseJSONFeedFile is like the package level ParseJSONFeedFile, but
+// respects o.
+func (o ParseOptions) ParseJSONFeedFile(path string) (*RSS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return o.ParseJSONFeedReader(f)
+}
+
+// ParseJSONFeedReader is like the package level ParseJSONFeedReader, but
+// respects o.
+func (o ParseOptions) ParseJSONFeedReader(r io.Reader) (*RSS, error) {
+	var feed jsonFeed
+	if err := json.NewDecoder(o.limitReader(r)).Decode(&feed); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(feed.Version, `https://jsonfeed.org/version/1`) {
+		return nil, &VersionError{Version: feed.Version}
+	}
+	state := &decodeState{opts: o}
+	ch, err := feed.channel(state)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.checkLimits(ch); err != nil {
+		return nil, err
+	}
+	rss := NewRSS(ch)
+	if len(state.warnings) > 0 {
+		return rss, state.warnings
+	}
+	return rss, nil
+}
+
+func (f *jsonFeed) channel(state *decodeState) (*Channel, error) {
+	ch := &Channel{
+		XMLName:     xml.Name{Local: `channel`},
+		Title:       f.Title,
+		Link:        f.HomePageURL,
+		Description: f.Description,
+		Language:    f.Language,
+	}
+	for _, link := range []struct{ href, rel string }{
+		{f.FeedURL, RelSelf},
+		{f.NextURL, RelNext},
+	} {
+		if len(link.href) > 0 {
+			l, _ := NewAtomLink(link.href, link.rel)
+			ch.AtomLinks = append(ch.AtomLinks, l)
+		}
+	}
+	for _, hub := range f.Hubs {
+		if len(hub.URL) > 0 {
+			l, _ := NewAtomLink(hub.URL, RelHub)
+			ch.AtomLinks = append(ch.AtomLinks, l)
+		}
+	}
+	var creators []string
+	ch.ManagingEditor, creators = rssPersons(f.Authors, f.Author)
+	if len(creators) > 0 {
+		ch.DC = &DublinCore{Creators: creators}
+	}
+	if len(f.Icon) > 0 {
+		ch.Image = &Image{URL: f.Icon, Title: ch.Title, Link: ch.Link}
+	}
+	if r := f.RSS; r != nil {
+		ch.Copyright = r.Copyright
+		ch.WebMaster = r.WebMaster
+		ch.Generator = r.Generator
+		ch.Docs = r.Docs
+		ch.TTL = r.TTL
+		ch.Rating = r.Rating
+		var err error
+		if ch.PubDate, err = parseJSONTime(state, `_rss.pubDate`, r.PubDate); err != nil {
+			return nil, err
+		}
+		if ch.LastBuildDate, err = parseJSONTime(state, `_rss.lastBuildDate`, r.LastBuildDate); err != nil {
+			return nil, err
+		}
+		ch.Categories = rssCategories(r.Categories)
+		if r.Cloud != nil {
+			ch.Cloud = (*Cloud)(r.Cloud)
+		}
+		if r.Image != nil {
+			ch.Image = (*Image)(r.Image)
+		}
+		if r.TextInput != nil {
+			ch.TextInput = (*TextInput)(r.TextInput)
+		}
+		if r.SkipHours != nil {
+			ch.SkipHours = &SkipHours{Hours: r.SkipHours}
+		}
+		if r.SkipDays != nil {
+			ch.SkipDays = &SkipDays{Days: r.SkipDays}
+		}
+	}
+	setXMLNames(ch)
+	for i, item := range f.Items {
+		it, err := item.item(state, fmt.Sprintf(`items[%d]`, i))
+		if err != nil {
+			return nil, err
+		}
+		ch.Items = append(ch.Items, it)
+	}
+	return ch, nil
+}
+
+func (j *jsonItem) item(state *decodeState, path string) (*Item, error) {
+	it := &Item{
+		XMLName:     xml.Name{Local: `item`},
+		Title:       j.Title,
+		Link:        j.URL,
+		Description: j.ContentHTML,
+	}
+	if len(j.Summary) > 0 && len(j.ContentHTML) > 0 {
+		it.Description = j.Summary
+		it.Content = &Content{XMLName: xml.Name{Local: `content:encoded`}, Value: j.ContentHTML}
+	} else if len(it.Description) == 0 {
+		if j.ContentText != nil {
+			it.Description = html.EscapeString(*j.ContentText)
+		}
+		if len(it.Description) == 0 {
+			it.Description = j.Summary
+		}
+	}
+	if len(j.ID) > 0 {
+		it.GUID = &GUID{XMLName: xml.Name{Local: `guid`}, Value: j.ID}
+	}
+	if len(j.ExternalURL) > 0 {
+		link, _ := NewAtomLink(j.ExternalURL, `related`)
+		it.AtomLinks = []*AtomLink{link}
+	}
+	var err error
+	date, element := j.DatePublished, `date_published`
+	if len(date) == 0 {
+		date, element = j.DateModified, `date_modified`
+	}
+	if it.PubDate, err = parseJSONTime(state, element, date); err != nil {
+		return nil, err
+	}
+	var creators []string
+	it.Author, creators = rssPersons(j.Authors, j.Author)
+	if len(creators) > 0 {
+		it.DC = &DublinCore{Creators: creators}
+	}
+	for _, tag := range j.Tags {
+		it.Categories = append(it.Categories, &Category{Value: tag})
+	}
+	for i, attachment := range j.Attachments {
+		if i > 0 {
+			state.warn(&UnrepresentableError{
+				Path:   fmt.Sprintf(`%s.attachments[%d]`, path, i),
+				Format: `RSS`,
+			})
+			continue
+		}
+		it.Enclosure = &Enclosure{
+			URL:    attachment.URL,
+			Length: attachment.SizeInBytes,
+			Type:   attachment.MIMEType,
+		}
+	}
+	if len(j.Image) > 0 {
+		thumbnail, _ := NewMediaThumbnail(j.Image, 0, 0)
+		it.Media = &MediaItem{
+			MediaElements: MediaElements{Thumbnails: []*MediaThumbnail{thumbnail}},
+		}
+	}
+	if r := j.RSS; r != nil {
+		if it.GUID != nil {
+			it.GUID.IsPermaLink = r.IsPermaLink
+		}
+		it.Comments = r.Comments
+		if r.Categories != nil {
+			it.Categories = rssCategories(r.Categories)
+		}
+		if r.Source != nil {
+			it.Source = (*Source)(r.Source)
+		}
+	}
+	setXMLNames(it)
+	return it, nil
+}
+
+// setXMLNames sets the XMLName of the elements of ch or it, which are
+// created by struct conversions or as literals above.
+func setXMLNames(v interface{}) {
+	switch v := v.(type) {
+	case *Channel:
+		if v.Cloud != nil {
+			v.Cloud.XMLName = xml.Name{Local: `cloud`}
+		}
+		if v.Image != nil {
+			v.Image.XMLName = xml.Name{Local: `image`}
+		}
+		if v.TextInput != nil {
+			v.TextInput.XMLName = xml.Name{Local: `textInput`}
+		}
+		if v.SkipHours != nil {
+			v.SkipHours.XMLName = xml.Name{Local: `skipHours`}
+		}
+		if v.SkipDays != nil {
+			v.SkipDays.XMLName = xml.Name{Local: `skipDays`}
+		}
+		for _, category := range v.Categories {
+			category.XMLName = xml.Name{Local: `category`}
+		}
+	case *Item:
+		if v.Enclosure != nil {
+			v.Enclosure.XMLName = xml.Name{Local: `enclosure`}
+		}
+		if v.Source != nil {
+			v.Source.XMLName = xml.Name{Local: `source`}
+		}
+		for _, category := range v.Categories {
+			category.XMLName = xml.Name{Local: `category`}
+		}
+	}
+}
+
+func rssCategories(categories []*jsonCategory) []*Category {
+	var converted []*Category
+	for _, category := range categories {
+		converted = append(converted, (*Category)(category))
+	}
+	return converted
+}
+
+// jsonAuthorOf converts the value of an RSS author or managingEditor
+// element.
+func jsonAuthorOf(s string) *jsonAuthor {
+	person := parseRSSPerson(s)
+	author := &jsonAuthor{Name: person.Name}
+	if len(person.Email) > 0 {
+		author.URL = `mailto:` + person.Email
+	}
+	return author
+}
+
+// rssPersons returns the first of authors, or else author of JSON Feed
+// 1.0, in the form of an RSS author element and the names of the
+// remaining authors.
+func rssPersons(authors []*jsonAuthor, author *jsonAuthor) (string, []string) {
+	if len(authors) == 0 && author != nil {
+		authors = []*jsonAuthor{author}
+	}
+	if len(authors) == 0 {
+		return ``, nil
+	}
+	var others []string
+	for _, a := range authors[1:] {
+		if len(a.Name) > 0 {
+			others = append(others, a.Name)
+		}
+	}
+	name := authors[0].Name
+	email := strings.TrimPrefix(authors[0].URL, `mailto:`)
+	switch {
+	case email == authors[0].URL || len(email) == 0:
+		return name, others
+	case len(name) == 0 || name == email:
+		return email, others
+	}
+	return email + ` (` + name + `)`, others
+}
+
+func formatJSONTime(t *RSSTime) string {
+	if t == nil {
+		return ``
+	} else if t.Time.IsZero() {
+		return t.Raw
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+func parseJSONTime(state *decodeState, field, value string) (*RSSTime, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	t, err := state.parseW3CTime(field, value)
+	if err != nil {
+		return nil, err
+	}
+	return &RSSTime{Time: t.Time, Raw: t.Raw}, nil
+}
@@ -0,0 +1,222 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONFeed(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	channel.Language = `en`
+	channel.Copyright = `© Jane Doe`
+	channel.ManagingEditor = `jane@foo.com (Jane Doe)`
+	channel.LastBuildDate = &RSSTime{Time: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)}
+	channel.TTL = 60
+	channel.Categories = []*Category{{XMLName: xml.Name{Local: `category`}, Value: `news`}}
+	channel.Image, _ = NewImage(`https://foo.com/logo.png`, `Channel title`, `https://foo.com/`)
+	channel.SkipDays = NewSkipDays([]time.Weekday{time.Sunday})
+	channel.AtomLinks = []*AtomLink{{XMLName: xml.Name{Local: `atom:link`}, Href: `https://foo.com/feed.json`, Rel: RelSelf}}
+	item1, _ := NewItem(`Item 1`, `Item <i>teaser</i>`)
+	item1.Link = `https://foo.com/1`
+	item1.GUID, _ = NewGUID(`https://foo.com/1`)
+	item1.GUID.IsPermaLink = true
+	item1.PubDate = &RSSTime{Time: time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)}
+	item1.Enclosure, _ = NewEnclosure(`https://foo.com/1.mp3`, 42, `audio/mpeg`)
+	item1.Content, _ = NewContent(`<p>Full content</p>`)
+	item1.Categories = []*Category{{XMLName: xml.Name{Local: `category`}, Value: `audio`}}
+	item1.Author = `john@foo.com`
+	item2, _ := NewItem(`Item 2`, `Second item`)
+	item2.GUID, _ = NewGUID(`42`)
+	item2.Comments = `https://foo.com/2#comments`
+	item2.Categories = []*Category{{XMLName: xml.Name{Local: `category`}, Value: `tech`, Domain: `https://foo.com/tags`}}
+	channel.Items = []*Item{item1, item2}
+
+	out, err := channel.JSONFeedBytes(WithIndent(``, `  `))
+	if err != nil {
+		t.Fatal("Failed to render:", err)
+	}
+	expected := `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Channel title",
+  "home_page_url": "https://foo.com/",
+  "feed_url": "https://foo.com/feed.json",
+  "description": "Channel description",
+  "icon": "https://foo.com/logo.png",
+  "authors": [
+    {
+      "name": "Jane Doe",
+      "url": "mailto:jane@foo.com"
+    }
+  ],
+  "language": "en",
+  "items": [
+    {
+      "id": "https://foo.com/1",
+      "url": "https://foo.com/1",
+      "title": "Item 1",
+      "content_html": "<p>Full content</p>",
+      "summary": "Item <i>teaser</i>",
+      "date_published": "2023-04-01T10:00:00Z",
+      "authors": [
+        {
+          "name": "john@foo.com",
+          "url": "mailto:john@foo.com"
+        }
+      ],
+      "tags": [
+        "audio"
+      ],
+      "attachments": [
+        {
+          "url": "https://foo.com/1.mp3",
+          "mime_type": "audio/mpeg",
+          "size_in_bytes": 42
+        }
+      ],
+      "_rss": {
+        "isPermaLink": true
+      }
+    },
+    {
+      "id": "42",
+      "title": "Item 2",
+      "content_html": "Second item",
+      "tags": [
+        "tech"
+      ],
+      "_rss": {
+        "comments": "https://foo.com/2#comments",
+        "categories": [
+          {
+            "value": "tech",
+            "domain": "https://foo.com/tags"
+          }
+        ]
+      }
+    }
+  ],
+  "_rss": {
+    "copyright": "© Jane Doe",
+    "lastBuildDate": "2023-04-01T12:00:00Z",
+    "categories": [
+      {
+        "value": "news"
+      }
+    ],
+    "ttl": 60,
+    "image": {
+      "url": "https://foo.com/logo.png",
+      "title": "Channel title",
+      "link": "https://foo.com/"
+    },
+    "skipDays": [
+      "Sunday"
+    ]
+  }
+}
+`
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Errorf("JSON Feed rendering mismatch (-want +got):\n%s", diff)
+	}
+
+	rss, err := ParseJSONFeed(out)
+	if err != nil {
+		t.Fatal("Failed to parse:", err)
+	}
+	if diff := cmp.Diff(NewRSS(channel), rss); diff != "" {
+		t.Errorf("JSON Feed round trip mismatch (-want +got):\n%s", diff)
+	}
+
+	channel.ITunes = &ITunesChannel{}
+	_, err = channel.JSONFeedBytes()
+	expectedWarnings := Warnings{&UnrepresentableError{Path: `channel/itunes:*`, Format: `JSON Feed`}}
+	if diff := cmp.Diff(expectedWarnings, err); diff != "" {
+		t.Errorf("Warnings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONFeedTitleOnlyItem(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	item, _ := NewItem(`t`, ``)
+	item.GUID, _ = NewGUID(`1`)
+	channel.Items = []*Item{item}
+	out, err := channel.JSONFeedBytes()
+	if err != nil {
+		t.Fatal("Failed to render:", err)
+	}
+	expected := `{"version":"https://jsonfeed.org/version/1.1","title":"Channel title",` +
+		`"home_page_url":"https://foo.com/","description":"Channel description",` +
+		`"items":[{"id":"1","title":"t","content_text":""}]}` + "\n"
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Errorf("JSON Feed rendering mismatch (-want +got):\n%s", diff)
+	}
+	rss, err := ParseJSONFeed(out)
+	if err != nil {
+		t.Fatal("Failed to parse rendered JSON Feed:", err)
+	}
+	if title, description := rss.Channel.Items[0].Title, rss.Channel.Items[0].Description; title != `t` || description != `` {
+		t.Errorf("Unexpected item with title '%s' and description '%s'", title, description)
+	}
+}
+
+func TestParseJSONFeed(t *testing.T) {
+	input := `{
+  "version": "https://jsonfeed.org/version/1",
+  "title": "Feed title",
+  "home_page_url": "https://foo.com/",
+  "author": {"name": "Jane Doe"},
+  "items": [
+    {
+      "id": "1",
+      "content_text": "Plain <text>",
+      "image": "https://foo.com/1.png",
+      "date_modified": "2023-04-01T10:00:00+02:00",
+      "authors": [{"name": "John"}, {"name": "Jim"}],
+      "attachments": [
+        {"url": "https://foo.com/1.mp3", "mime_type": "audio/mpeg"},
+        {"url": "https://foo.com/1.ogg", "mime_type": "audio/ogg"}
+      ]
+    }
+  ]
+}`
+	thumbnail, _ := NewMediaThumbnail(`https://foo.com/1.png`, 0, 0)
+	expected := NewRSS(&Channel{
+		XMLName:        xml.Name{Local: `channel`},
+		Title:          `Feed title`,
+		Link:           `https://foo.com/`,
+		ManagingEditor: `Jane Doe`,
+		Items: []*Item{{
+			XMLName:     xml.Name{Local: `item`},
+			Description: `Plain &lt;text&gt;`,
+			Author:      `John`,
+			Enclosure: &Enclosure{
+				XMLName: xml.Name{Local: `enclosure`},
+				URL:     `https://foo.com/1.mp3`,
+				Type:    `audio/mpeg`,
+			},
+			GUID:    &GUID{XMLName: xml.Name{Local: `guid`}, Value: `1`},
+			PubDate: &RSSTime{Time: time.Date(2023, 4, 1, 10, 0, 0, 0, time.FixedZone(``, 2*60*60))},
+			DC:      &DublinCore{Creators: []string{`Jim`}},
+			Media: &MediaItem{
+				MediaElements: MediaElements{Thumbnails: []*MediaThumbnail{thumbnail}},
+			},
+		}},
+	})
+	rss, err := ParseJSONFeed([]byte(input))
+	expectedWarnings := Warnings{&UnrepresentableError{Path: `items[0].attachments[1]`, Format: `RSS`}}
+	if diff := cmp.Diff(expectedWarnings, err); diff != "" {
+		t.Errorf("Warnings mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expected, rss); diff != "" {
+		t.Errorf("JSON Feed parsing mismatch (-want +got):\n%s", diff)
+	}
+
+	var versionErr *VersionError
+	if _, err = ParseJSONFeed([]byte(`{"version": "2"}`)); !errors.As(err, &versionErr) {
+		t.Errorf("Expected VersionError, got '%v'", err)
+	}
+}
@@ -0,0 +1,135 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// The namespaces of the RDF based versions of RSS.
+const (
+	rss090Namespace = `http://my.netscape.com/rdf/simple/0.9/`
+	rss10Namespace  = `http://purl.org/rss/1.0/`
+)
+
+// legacyNames maps the names of elements of RSS 0.9x and 1.0, that have
+// been renamed in RSS 2.0, to their new names.
+var legacyNames = map[string]string{
+	`textinput`: `textInput`,
+}
+
+func isLegacyVersion(version string) bool {
+	switch version {
+	case `0.91`, `0.92`, `0.93`, `0.94`:
+		return true
+	}
+	return false
+}
+
+func isLegacyNamespace(namespace string) bool {
+	return namespace == rss090Namespace || namespace == rss10Namespace
+}
+
+// rdf represents the rdf:RDF root element of RSS 0.90 and 1.0
+// documents. Images, items and text inputs are siblings of the channel
+// there.
+type rdf struct {
+	Channel   *Channel   `xml:"channel"`
+	Image     *Image     `xml:"image"`
+	Items     []*Item    `xml:"item"`
+	TextInput *TextInput `xml:"textInput"`
+}
+
+// upgrade turns r, which has been parsed from an RSS 0.9x document, into
+// RSS 2.0.
+func (r *RSS) upgrade() {
+	r.SourceVersion, r.Version = r.Version, `2.0`
+	if r.Channel != nil {
+		r.Channel.upgradeSkipHours()
+	}
+}
+
+// upgradeSkipHours maps the hours of RSS 0.91, which are numbered from
+// 1 to 24, to those of RSS 2.0.
+func (ch *Channel) upgradeSkipHours() {
+	if ch.SkipHours != nil {
+		for i, hour := range ch.SkipHours.Hours {
+			ch.SkipHours.Hours[i] = hour % 24
+		}
+	}
+}
+
+// decodeRDF decodes the rdf:RDF element started by start and upgrades
+// it to RSS 2.0.
+func decodeRDF(decoder *xml.Decoder, start xml.StartElement) (*RSS, error) {
+	var doc rdf
+	if err := decodePrefixed(decoder, start, &doc, true); err != nil {
+		return nil, err
+	}
+	return doc.upgrade(rdfVersion(start)), nil
+}
+
+// upgrade turns doc into RSS 2.0. version is the version of RSS doc has
+// been parsed from.
+func (doc *rdf) upgrade(version string) *RSS {
+	rss := NewRSS(doc.Channel)
+	rss.SourceVersion = version
+	ch := doc.Channel
+	if ch == nil {
+		return rss
+	}
+	// The channel only references the image, items and text input by
+	// their URIs.
+	ch.Image, ch.TextInput = doc.Image, doc.TextInput
+	ch.Items = doc.Items
+	var extensions []*Element
+	for _, el := range ch.Extensions {
+		if el.XMLName.Local != `items` {
+			extensions = append(extensions, el)
+		}
+	}
+	ch.Extensions = extensions
+	ch.Attrs, _ = withoutRDFAttrs(ch.Attrs)
+	for _, item := range ch.Items {
+		item.upgradeRDF()
+	}
+	return rss
+}
+
+// upgradeRDF turns the rdf:about attribute of it into a GUID.
+func (it *Item) upgradeRDF() {
+	var about string
+	it.Attrs, about = withoutRDFAttrs(it.Attrs)
+	if it.GUID == nil && len(about) > 0 {
+		it.GUID = &GUID{
+			XMLName:     xml.Name{Local: `guid`},
+			Value:       about,
+			IsPermaLink: about == strings.TrimSpace(it.Link),
+		}
+	}
+}
+
+// rdfVersion returns the version of RSS of the rdf:RDF element started by
+// start.
+func rdfVersion(start xml.StartElement) string {
+	for _, attr := range start.Attr {
+		if attr.Value == rss090Namespace {
+			return `0.90`
+		}
+	}
+	return `1.0`
+}
+
+// withoutRDFAttrs removes the attributes of the RDF namespace from
+// attrs and returns the value of rdf:about.
+func withoutRDFAttrs(attrs []xml.Attr) ([]xml.Attr, string) {
+	var remaining []xml.Attr
+	var about string
+	for _, attr := range attrs {
+		if attr.Name.Local == `rdf:about` {
+			about = attr.Value
+		} else if !strings.HasPrefix(attr.Name.Local, `rdf:`) {
+			remaining = append(remaining, attr)
+		}
+	}
+	return remaining, about
+}
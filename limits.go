@@ -0,0 +1,150 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// LimitError is returned by the parse functions of ParseOptions, if a
+// document exceeds one of the configured limits. Limit is the name of
+// the exceeded field of ParseOptions, e.g. "MaxItems", and Max its
+// value.
+type LimitError struct {
+	Limit string
+	Max   int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf(`document exceeds %s of %d`, e.Limit, e.Max)
+}
+
+// hasTokenLimits reports whether o limits the tokens of XML documents.
+func (o ParseOptions) hasTokenLimits() bool {
+	return o.MaxDepth > 0 || o.MaxAttrs > 0 || o.MaxItems > 0 || o.MaxDescriptionLength > 0
+}
+
+// newDecoder is like the package level newDecoder, but the returned
+// decoder respects the limits of o.
+func (o ParseOptions) newDecoder(r io.Reader) *xml.Decoder {
+	decoder := newDecoder(o.limitReader(r))
+	if !o.hasTokenLimits() {
+		return decoder
+	}
+	return xml.NewTokenDecoder(&limitTokenReader{r: decoder, opts: o})
+}
+
+// limitReader returns a reader, that fails with a LimitError, once more
+// than o.MaxBytes have been read from r.
+func (o ParseOptions) limitReader(r io.Reader) io.Reader {
+	if o.MaxBytes <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, remaining: o.MaxBytes, max: o.MaxBytes}
+}
+
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	max       int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, &LimitError{Limit: `MaxBytes`, Max: l.max}
+	}
+	// Reading one byte more than allowed reveals whether the limit is
+	// exceeded.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n - 1, &LimitError{Limit: `MaxBytes`, Max: l.max}
+	}
+	return n, err
+}
+
+// limitTokenReader is an xml.TokenReader that reads tokens from r and
+// fails with a LimitError, once they exceed the limits of opts.
+type limitTokenReader struct {
+	r     xml.TokenReader
+	opts  ParseOptions
+	names []string
+	items int
+	// descriptionLength is the length of the text of the description
+	// element currently read.
+	descriptionLength int
+}
+
+func (l *limitTokenReader) Token() (xml.Token, error) {
+	token, err := l.r.Token()
+	if err != nil {
+		return token, err
+	}
+	o := l.opts
+	switch t := token.(type) {
+	case xml.StartElement:
+		if o.MaxDepth > 0 && len(l.names) >= o.MaxDepth {
+			return nil, &LimitError{Limit: `MaxDepth`, Max: int64(o.MaxDepth)}
+		}
+		if o.MaxAttrs > 0 && len(t.Attr) > o.MaxAttrs {
+			return nil, &LimitError{Limit: `MaxAttrs`, Max: int64(o.MaxAttrs)}
+		}
+		if o.MaxItems > 0 && l.isItem(t.Name.Local) {
+			if l.items++; l.items > o.MaxItems {
+				return nil, &LimitError{Limit: `MaxItems`, Max: int64(o.MaxItems)}
+			}
+		}
+		l.names = append(l.names, t.Name.Local)
+		l.descriptionLength = 0
+	case xml.EndElement:
+		if len(l.names) > 0 {
+			l.names = l.names[:len(l.names)-1]
+		}
+	case xml.CharData:
+		if o.MaxDescriptionLength > 0 && len(l.names) > 0 && l.names[len(l.names)-1] == `description` {
+			if l.descriptionLength += len(t); l.descriptionLength > o.MaxDescriptionLength {
+				return nil, &LimitError{Limit: `MaxDescriptionLength`, Max: int64(o.MaxDescriptionLength)}
+			}
+		}
+	}
+	return token, nil
+}
+
+// isItem reports whether an element with the given name, starting at
+// the current position, is an item of RSS or RDF or an entry of Atom.
+func (l *limitTokenReader) isItem(name string) bool {
+	if len(l.names) == 0 {
+		return false
+	}
+	switch parent := l.names[len(l.names)-1]; name {
+	case `item`:
+		return parent == `channel` || parent == `RDF`
+	case `entry`:
+		return parent == `feed`
+	}
+	return false
+}
+
+// checkLimits checks the items and descriptions of ch, which has been
+// converted from another format, against the limits of o.
+func (o ParseOptions) checkLimits(ch *Channel) error {
+	if o.MaxItems > 0 && len(ch.Items) > o.MaxItems {
+		return &LimitError{Limit: `MaxItems`, Max: int64(o.MaxItems)}
+	}
+	if o.MaxDescriptionLength <= 0 {
+		return nil
+	}
+	err := &LimitError{Limit: `MaxDescriptionLength`, Max: int64(o.MaxDescriptionLength)}
+	if len(ch.Description) > o.MaxDescriptionLength {
+		return err
+	}
+	for _, item := range ch.Items {
+		if len(item.Description) > o.MaxDescriptionLength {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package rss2
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLimits(t *testing.T) {
+	generous := ParseOptions{
+		MaxBytes:             1 << 20,
+		MaxDepth:             20,
+		MaxAttrs:             20,
+		MaxItems:             100,
+		MaxDescriptionLength: 1 << 10,
+	}
+	for _, tc := range xmlToRSSTestCases {
+		parse, err := generous.Parse([]byte(tc.Input))
+		if err != nil {
+			t.Errorf("Unexpected error '%v'", err)
+			continue
+		}
+		if diff := cmp.Diff(tc.Expected, *parse); diff != "" {
+			t.Errorf("RSS parsing mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	input := `<rss version="2.0"><channel>
+		<title>Channel title</title>
+		<link>https://foo.com/</link>
+		<description>Channel description</description>
+		<item><description>0123456789</description></item>
+		<item><title a="1" b="2" c="3">Item</title><source url="https://bar.com/"><x><y/></x></source></item>
+	</channel></rss>`
+	for _, tc := range []struct {
+		Opts  ParseOptions
+		Limit string
+	}{
+		{ParseOptions{MaxBytes: 100}, `MaxBytes`},
+		{ParseOptions{MaxDepth: 5}, `MaxDepth`},
+		{ParseOptions{MaxAttrs: 2}, `MaxAttrs`},
+		{ParseOptions{MaxItems: 1}, `MaxItems`},
+		{ParseOptions{MaxDescriptionLength: 9}, `MaxDescriptionLength`},
+	} {
+		var limitErr *LimitError
+		if _, err := tc.Opts.Parse([]byte(input)); !errors.As(err, &limitErr) {
+			t.Errorf("Expected LimitError for %s, got '%v'", tc.Limit, err)
+		} else if limitErr.Limit != tc.Limit {
+			t.Errorf("Expected exceeded limit %s, got %s", tc.Limit, limitErr.Limit)
+		}
+	}
+	exact := ParseOptions{
+		MaxBytes:             int64(len(input)),
+		MaxDepth:             6,
+		MaxAttrs:             3,
+		MaxItems:             2,
+		MaxDescriptionLength: 19,
+	}
+	if _, err := exact.Parse([]byte(input)); err != nil {
+		t.Errorf("Unexpected error '%v'", err)
+	}
+
+	var limitErr *LimitError
+	decoder := ParseOptions{MaxItems: 1}.NewDecoder(strings.NewReader(input))
+	if _, err := decoder.Next(); err != nil {
+		t.Errorf("Unexpected error '%v'", err)
+	}
+	if _, err := decoder.Next(); !errors.As(err, &limitErr) {
+		t.Errorf("Expected LimitError, got '%v'", err)
+	}
+	atom := `<feed xmlns="http://www.w3.org/2005/Atom"><title>Feed</title>
+		<entry><title>1</title></entry><entry><title>2</title></entry></feed>`
+	if _, err := (ParseOptions{MaxItems: 1}).ParseAtom([]byte(atom)); !errors.As(err, &limitErr) {
+		t.Errorf("Expected LimitError for Atom, got '%v'", err)
+	}
+	json := `{"version": "https://jsonfeed.org/version/1.1", "title": "Feed",
+		"items": [{"id": "1", "summary": "0123456789"}]}`
+	if _, err := (ParseOptions{MaxDescriptionLength: 9}).ParseJSONFeed([]byte(json)); !errors.As(err, &limitErr) {
+		t.Errorf("Expected LimitError for JSON Feed, got '%v'", err)
+	}
+	if _, err := (ParseOptions{MaxBytes: 50}).ParseJSONFeed([]byte(json)); !errors.As(err, &limitErr) {
+		t.Errorf("Expected LimitError for JSON Feed, got '%v'", err)
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestParseAnyMaxBytes(t *testing.T) {
+	for _, input := range []string{
+		// Detect reads JSON up to the version.
+		`{"items": [` + strings.Repeat(`{"id": "1", "title": "Item"},`, 100000) +
+			`{"id": "2"}], "version": "https://jsonfeed.org/version/1.1", "title": "Feed"}`,
+		// Detect reads XML up to the root element.
+		`<?xml version="1.0"?><!--` + strings.Repeat(`comment `, 100000) + `--><rss version="2.0"></rss>`,
+	} {
+		source := &countingReader{r: strings.NewReader(input)}
+		var limitErr *LimitError
+		if _, err := (ParseOptions{MaxBytes: 1000}).ParseAnyReader(source); !errors.As(err, &limitErr) {
+			t.Errorf("Expected LimitError, got '%v'", err)
+		}
+		if source.n > 1001 {
+			t.Errorf("Read %d bytes from the source despite MaxBytes of 1000", source.n)
+		}
+	}
+}
+
+func TestParseEntities(t *testing.T) {
+	for _, input := range []string{
+		// Exponential entity expansion ("billion laughs").
+		`<?xml version="1.0"?>
+<!DOCTYPE rss [
+  <!ENTITY lol "lol">
+  <!ENTITY lol1 "&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;">
+  <!ENTITY lol2 "&lol1;&lol1;&lol1;&lol1;&lol1;&lol1;&lol1;&lol1;&lol1;&lol1;">
+  <!ENTITY lol3 "&lol2;&lol2;&lol2;&lol2;&lol2;&lol2;&lol2;&lol2;&lol2;&lol2;">
+]>
+<rss version="2.0"><channel>
+  <title>&lol3;</title><link>https://foo.com/</link><description>Description</description>
+</channel></rss>`,
+		// External entities.
+		`<?xml version="1.0"?>
+<!DOCTYPE rss [<!ENTITY xxe SYSTEM "file:///etc/passwd">]>
+<rss version="2.0"><channel>
+  <title>&xxe;</title><link>https://foo.com/</link><description>Description</description>
+</channel></rss>`,
+		`<?xml version="1.0"?>
+<!DOCTYPE feed [<!ENTITY xxe SYSTEM "file:///etc/passwd">]>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>&xxe;</title></feed>`,
+	} {
+		for _, opts := range []ParseOptions{{}, {MaxDepth: 10}} {
+			if _, err := opts.ParseAny([]byte(input)); err == nil {
+				t.Errorf("Expected error for entity references in '%s'", input)
+			}
+		}
+	}
+}
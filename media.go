@@ -0,0 +1,299 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// MediaElements holds the optional Media RSS elements, that may appear
+// in items, groups and contents alike. Elements of a MediaContent take
+// precedence over those of its MediaGroup, which in turn take
+// precedence over those of the Item.
+type MediaElements struct {
+	Title       *MediaText        `xml:"media:title,omitempty"`
+	Description *MediaText        `xml:"media:description,omitempty"`
+	Thumbnails  []*MediaThumbnail `xml:"media:thumbnail,omitempty"`
+	Credits     []*MediaCredit    `xml:"media:credit,omitempty"`
+}
+
+// MediaItem holds the Media RSS elements of an item. Contents are media
+// objects on their own, while each of Groups contains different
+// versions of the same media object.
+type MediaItem struct {
+	Contents []*MediaContent `xml:"media:content,omitempty"`
+	Groups   []*MediaGroup   `xml:"media:group,omitempty"`
+	MediaElements
+}
+
+// MediaGroup represents a media:group element. It must contain at
+// least one MediaContent.
+type MediaGroup struct {
+	XMLName  xml.Name        `xml:"media:group"`
+	Contents []*MediaContent `xml:"media:content"`
+	MediaElements
+}
+
+// MediaContent represents a media:content element. URL must be
+// present. Medium must be "image", "audio", "video", "document" or
+// "executable" and Expression "sample", "full" or "nonstop", if
+// present. Duration is given in seconds, Bitrate in kilobits per
+// second and SamplingRate in kilosamples per second.
+type MediaContent struct {
+	XMLName      xml.Name `xml:"media:content"`
+	URL          string   `xml:"url,attr"`
+	FileSize     int64    `xml:"fileSize,attr,omitempty"`
+	Type         string   `xml:"type,attr,omitempty"`
+	Medium       string   `xml:"medium,attr,omitempty"`
+	IsDefault    bool     `xml:"isDefault,attr,omitempty"`
+	Expression   string   `xml:"expression,attr,omitempty"`
+	Bitrate      float64  `xml:"bitrate,attr,omitempty"`
+	Framerate    float64  `xml:"framerate,attr,omitempty"`
+	SamplingRate float64  `xml:"samplingrate,attr,omitempty"`
+	Channels     int      `xml:"channels,attr,omitempty"`
+	Duration     int      `xml:"duration,attr,omitempty"`
+	Height       int      `xml:"height,attr,omitempty"`
+	Width        int      `xml:"width,attr,omitempty"`
+	Lang         string   `xml:"lang,attr,omitempty"`
+	MediaElements
+}
+
+// MediaThumbnail represents a media:thumbnail element. URL must be
+// present. Time is an NTP time offset into the media object, e.g.
+// "12:05:01.123".
+type MediaThumbnail struct {
+	XMLName xml.Name `xml:"media:thumbnail"`
+	URL     string   `xml:"url,attr"`
+	Height  int      `xml:"height,attr,omitempty"`
+	Width   int      `xml:"width,attr,omitempty"`
+	Time    string   `xml:"time,attr,omitempty"`
+}
+
+// MediaText represents a media:title or media:description element.
+// Type must be "plain" or "html", if present.
+type MediaText struct {
+	Value string `xml:",chardata"`
+	Type  string `xml:"type,attr,omitempty"`
+}
+
+// MediaCredit represents a media:credit element. Role is optional and
+// is interpreted according to Scheme, which defaults to
+// "urn:ebu".
+type MediaCredit struct {
+	XMLName xml.Name `xml:"media:credit"`
+	Value   string   `xml:",chardata"`
+	Role    string   `xml:"role,attr,omitempty"`
+	Scheme  string   `xml:"scheme,attr,omitempty"`
+}
+
+// NewMediaGroup creates a new MediaGroup element.
+func NewMediaGroup(contents ...*MediaContent) (*MediaGroup, error) {
+	if len(contents) == 0 {
+		return nil, fmt.Errorf(`no contents passed to NewMediaGroup()`)
+	}
+	return &MediaGroup{
+		XMLName:  xml.Name{Local: `media:group`},
+		Contents: contents,
+	}, nil
+}
+
+// NewMediaContent creates a new MediaContent element.
+func NewMediaContent(url string) (*MediaContent, error) {
+	if len(url) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewMediaContent()`)
+	}
+	return &MediaContent{
+		XMLName: xml.Name{Local: `media:content`},
+		URL:     url,
+	}, nil
+}
+
+// NewMediaThumbnail creates a new MediaThumbnail element. width and
+// height may be 0, if unknown.
+func NewMediaThumbnail(url string, width, height int) (*MediaThumbnail, error) {
+	if len(url) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewMediaThumbnail()`)
+	}
+	return &MediaThumbnail{
+		XMLName: xml.Name{Local: `media:thumbnail`},
+		URL:     url,
+		Width:   width,
+		Height:  height,
+	}, nil
+}
+
+// NewMediaText creates a new MediaText element of the type "plain".
+func NewMediaText(value string) (*MediaText, error) {
+	if len(value) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewMediaText()`)
+	}
+	return &MediaText{Value: value, Type: `plain`}, nil
+}
+
+// NewMediaCredit creates a new MediaCredit element. role may be empty.
+func NewMediaCredit(value, role string) (*MediaCredit, error) {
+	if len(value) == 0 {
+		return nil, fmt.Errorf(`empty value passed to NewMediaCredit()`)
+	}
+	return &MediaCredit{
+		XMLName: xml.Name{Local: `media:credit`},
+		Value:   value,
+		Role:    role,
+	}, nil
+}
+
+// ThumbnailURL returns the URL of the largest thumbnail of the item.
+// Thumbnails of the item's Media RSS contents and groups are
+// considered as well. If there is no thumbnail, the URL of an image
+// content or enclosure, or the item's itunes:image is returned. An
+// empty string is returned, if none of them is present.
+func (it *Item) ThumbnailURL() string {
+	var best *MediaThumbnail
+	it.eachMediaElements(func(e *MediaElements) {
+		for _, thumbnail := range e.Thumbnails {
+			if best == nil || thumbnail.Width*thumbnail.Height > best.Width*best.Height {
+				best = thumbnail
+			}
+		}
+	})
+	if best != nil {
+		return best.URL
+	}
+	var bestImage *MediaContent
+	it.eachMediaContent(func(content *MediaContent) {
+		if content.isImage() && (bestImage == nil ||
+			content.Width*content.Height > bestImage.Width*bestImage.Height) {
+			bestImage = content
+		}
+	})
+	if bestImage != nil {
+		return bestImage.URL
+	}
+	if it.Enclosure != nil && strings.HasPrefix(it.Enclosure.Type, `image/`) {
+		return it.Enclosure.URL
+	}
+	if it.ITunes != nil && it.ITunes.Image != nil {
+		return it.ITunes.Image.Href
+	}
+	return ``
+}
+
+// MediaURL returns the URL of the item's main media object. This is the
+// Media RSS content marked as default or else the one with the highest
+// resolution or bitrate. If there is no Media RSS content, the URL of
+// the enclosure is returned. An empty string is returned, if there is
+// no enclosure either.
+func (it *Item) MediaURL() string {
+	var best *MediaContent
+	it.eachMediaContent(func(content *MediaContent) {
+		switch {
+		case best == nil || content.IsDefault && !best.IsDefault:
+			best = content
+		case best.IsDefault || content.IsDefault:
+		case content.Width*content.Height > best.Width*best.Height,
+			content.Width*content.Height == best.Width*best.Height &&
+				content.Bitrate > best.Bitrate:
+			best = content
+		}
+	})
+	if best != nil {
+		return best.URL
+	}
+	if it.Enclosure != nil {
+		return it.Enclosure.URL
+	}
+	return ``
+}
+
+// eachMediaElements calls f for the MediaElements of the item, its
+// groups and all contents.
+func (it *Item) eachMediaElements(f func(*MediaElements)) {
+	if it.Media == nil {
+		return
+	}
+	f(&it.Media.MediaElements)
+	for _, group := range it.Media.Groups {
+		f(&group.MediaElements)
+	}
+	it.eachMediaContent(func(content *MediaContent) {
+		f(&content.MediaElements)
+	})
+}
+
+// eachMediaContent calls f for all contents of the item, including those
+// in groups.
+func (it *Item) eachMediaContent(f func(*MediaContent)) {
+	if it.Media == nil {
+		return
+	}
+	for _, content := range it.Media.Contents {
+		f(content)
+	}
+	for _, group := range it.Media.Groups {
+		for _, content := range group.Contents {
+			f(content)
+		}
+	}
+}
+
+func (c *MediaContent) isImage() bool {
+	return c.Medium == `image` || strings.HasPrefix(c.Type, `image/`)
+}
+
+func (m *MediaItem) validate(path string) (errs ValidationErrors) {
+	for i, content := range m.Contents {
+		errs = append(errs, content.validate(indexPath(path, `media:content`, i))...)
+	}
+	for i, group := range m.Groups {
+		errs = append(errs, group.validate(indexPath(path, `media:group`, i))...)
+	}
+	errs = append(errs, m.MediaElements.validate(path)...)
+	return
+}
+
+func (g *MediaGroup) validate(path string) (errs ValidationErrors) {
+	if len(g.Contents) == 0 {
+		errs.add(path+`/media:content`, `must be present`)
+	}
+	for i, content := range g.Contents {
+		errs = append(errs, content.validate(indexPath(path, `media:content`, i))...)
+	}
+	errs = append(errs, g.MediaElements.validate(path)...)
+	return
+}
+
+func (c *MediaContent) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@url`, c.URL)
+	if len(c.Medium) > 0 {
+		errs.requireOneOf(path+`@medium`, c.Medium,
+			`image`, `audio`, `video`, `document`, `executable`)
+	}
+	if len(c.Expression) > 0 {
+		errs.requireOneOf(path+`@expression`, c.Expression, `sample`, `full`, `nonstop`)
+	}
+	errs = append(errs, c.MediaElements.validate(path)...)
+	return
+}
+
+func (e *MediaElements) validate(path string) (errs ValidationErrors) {
+	if e.Title != nil {
+		errs = append(errs, e.Title.validate(path+`/media:title`)...)
+	}
+	if e.Description != nil {
+		errs = append(errs, e.Description.validate(path+`/media:description`)...)
+	}
+	for i, thumbnail := range e.Thumbnails {
+		errs.requireNonEmpty(indexPath(path, `media:thumbnail`, i)+`@url`, thumbnail.URL)
+	}
+	for i, credit := range e.Credits {
+		errs.requireNonEmpty(indexPath(path, `media:credit`, i), credit.Value)
+	}
+	return
+}
+
+func (t *MediaText) validate(path string) (errs ValidationErrors) {
+	if len(t.Type) > 0 {
+		errs.requireOneOf(path+`@type`, t.Type, `plain`, `html`)
+	}
+	return
+}
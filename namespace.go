@@ -0,0 +1,204 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The namespaces of the RSS extensions supported by this package.
+const (
+	ContentNamespace    = `http://purl.org/rss/1.0/modules/content/`
+	DublinCoreNamespace = `http://purl.org/dc/elements/1.1/`
+	AtomNamespace       = `http://www.w3.org/2005/Atom`
+	ITunesNamespace     = `http://www.itunes.com/dtds/podcast-1.0.dtd`
+	PodcastNamespace    = `https://podcastindex.org/namespace/1.0`
+	MediaNamespace      = `http://search.yahoo.com/mrss/`
+)
+
+const (
+	xmlNamespace = `http://www.w3.org/XML/1998/namespace`
+	rdfNamespace = `http://www.w3.org/1999/02/22-rdf-syntax-ns#`
+)
+
+// namespacePrefixes maps the URIs of known namespaces to the prefixes
+// used for them. Elements of these namespaces are identified by their
+// prefixed names in struct tags, e.g. "content:encoded". When parsing,
+// the prefixes chosen by a document are replaced with these.
+//
+// When an RSS is marshalled, the namespaces in use are declared on the
+// rss element. Channels and Items marshalled on their own lack these
+// declarations.
+var namespacePrefixes = map[string]string{
+	xmlNamespace:        `xml`,
+	rdfNamespace:        `rdf`,
+	ContentNamespace:    `content`,
+	DublinCoreNamespace: `dc`,
+	AtomNamespace:       `atom`,
+	ITunesNamespace:     `itunes`,
+	PodcastNamespace:    `podcast`,
+	MediaNamespace:      `media`,
+}
+
+// prefixReader is an xml.TokenReader that reads the element started by
+// start from decoder. Names of known namespaces are replaced with their
+// prefixed form, so that they match the struct tags of this package.
+// Names of other namespaces are given the prefix declared for them by
+// the document, unless a known namespace uses it. Such prefixes are
+// renamed along with their declaration, e.g. to "media2", so that the
+// elements are kept in Extensions instead of being mistaken for those
+// of the known namespace. Declarations of known namespaces are dropped,
+// since they are added again when marshalling; the others are turned
+// into ordinary attributes.
+//
+// In legacy mode, the namespaces of RSS 0.90 and 1.0 are treated like
+// no namespace, so their default declarations are dropped as well, and
+// names are renamed according to legacyNames.
+type prefixReader struct {
+	decoder  *xml.Decoder
+	start    *xml.StartElement
+	depth    int
+	prefixes map[string]string
+	legacy   bool
+}
+
+func newPrefixReader(decoder *xml.Decoder, start xml.StartElement, legacy bool) *prefixReader {
+	return &prefixReader{
+		decoder:  decoder,
+		start:    &start,
+		prefixes: make(map[string]string),
+		legacy:   legacy,
+	}
+}
+
+func (p *prefixReader) Token() (xml.Token, error) {
+	var token xml.Token
+	if p.start != nil {
+		token, p.start = *p.start, nil
+	} else if p.depth == 0 {
+		return nil, io.EOF
+	} else {
+		var err error
+		if token, err = p.decoder.Token(); err != nil {
+			return nil, err
+		}
+	}
+	switch t := token.(type) {
+	case xml.StartElement:
+		p.depth++
+		var attrs []xml.Attr
+		for _, attr := range t.Attr {
+			if p.legacy && attr.Name == (xml.Name{Local: `xmlns`}) && isLegacyNamespace(attr.Value) {
+				continue
+			}
+			if attr.Name.Space == `xmlns` {
+				if _, ok := namespacePrefixes[attr.Value]; ok {
+					continue
+				}
+				if _, ok := p.prefixes[attr.Value]; !ok {
+					p.prefixes[attr.Value] = p.unusedPrefix(attr.Name.Local)
+				}
+				attr.Name.Local = p.prefixes[attr.Value]
+			}
+			attrs = append(attrs, attr)
+		}
+		for i, attr := range attrs {
+			attrs[i] = xml.Attr{Name: p.prefixedName(attr.Name), Value: attr.Value}
+		}
+		t.Name = p.prefixedName(t.Name)
+		t.Attr = attrs
+		return t, nil
+	case xml.EndElement:
+		p.depth--
+		t.Name = p.prefixedName(t.Name)
+		return t, nil
+	}
+	return token, nil
+}
+
+func (p *prefixReader) prefixedName(name xml.Name) xml.Name {
+	if p.legacy && isLegacyNamespace(name.Space) {
+		name.Space = ``
+	}
+	if p.legacy && name.Space == `` {
+		if renamed, ok := legacyNames[name.Local]; ok {
+			name.Local = renamed
+		}
+	}
+	if name.Space == `` {
+		return name
+	} else if name.Space == `xmlns` {
+		return xml.Name{Local: `xmlns:` + name.Local}
+	} else if prefix, ok := namespacePrefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + `:` + name.Local}
+	} else if prefix, ok := p.prefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + `:` + name.Local}
+	} else if !strings.Contains(name.Space, `:`) {
+		// xml.Decoder leaves undeclared prefixes in Space. URIs
+		// always contain a colon.
+		return xml.Name{Local: name.Space + `:` + name.Local}
+	}
+	return name
+}
+
+// unusedPrefix returns prefix or, if it is used by a known namespace
+// or already given to another namespace, prefix followed by a number.
+func (p *prefixReader) unusedPrefix(prefix string) string {
+	unused := prefix
+	for i := 2; p.isPrefixUsed(unused); i++ {
+		unused = prefix + strconv.Itoa(i)
+	}
+	return unused
+}
+
+func (p *prefixReader) isPrefixUsed(prefix string) bool {
+	if isKnownPrefix(prefix) {
+		return true
+	}
+	for _, used := range p.prefixes {
+		if used == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownPrefix reports whether prefix is used by a known namespace.
+func isKnownPrefix(prefix string) bool {
+	for _, known := range namespacePrefixes {
+		if known == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// decodePrefixed decodes the element started by start into v, with the
+// names of known namespaces in their prefixed form. See prefixReader
+// for legacy.
+func decodePrefixed(decoder *xml.Decoder, start xml.StartElement, v interface{}, legacy bool) error {
+	prefixed := xml.NewTokenDecoder(newPrefixReader(decoder, start, legacy))
+	if state, ok := decodeStates.Load(decoder); ok {
+		decodeStates.Store(prefixed, state)
+		defer decodeStates.Delete(prefixed)
+	}
+	return prefixed.Decode(v)
+}
+
+// namespaceAttrs returns the declarations for the given namespaces,
+// sorted by prefix.
+func namespaceAttrs(namespaces map[string]bool) []xml.Attr {
+	var attrs []xml.Attr
+	for namespace := range namespaces {
+		attrs = append(attrs, xml.Attr{
+			Name:  xml.Name{Local: `xmlns:` + namespacePrefixes[namespace]},
+			Value: namespace,
+		})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+	return attrs
+}
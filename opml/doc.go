@@ -0,0 +1,6 @@
+/*
+Package opml let's you parse, modify, create and render OPML 2.0
+documents, which are commonly used to exchange lists of feed
+subscriptions. Specification was taken from http://opml.org/spec2.opml .
+*/
+package opml
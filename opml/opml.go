@@ -0,0 +1,166 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/codesoap/rss2"
+)
+
+// OPML represents an opml document. Version is "2.0" for documents
+// created by NewOPML; documents of version "1.0" can be parsed as well.
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    *Head    `xml:"head"`
+	Body    *Body    `xml:"body"`
+}
+
+// Head represents the head element of an OPML document. All fields are
+// optional. DateCreated and DateModified are parsed leniently and never
+// cause an error, since many documents do not use RFC 822 dates. Dates
+// that cannot be parsed at all are kept in their Raw field.
+type Head struct {
+	XMLName      xml.Name      `xml:"head"`
+	Title        string        `xml:"title,omitempty"`
+	DateCreated  *rss2.RSSTime `xml:"dateCreated,omitempty"`
+	DateModified *rss2.RSSTime `xml:"dateModified,omitempty"`
+	OwnerName    string        `xml:"ownerName,omitempty"`
+	OwnerEmail   string        `xml:"ownerEmail,omitempty"`
+	OwnerID      string        `xml:"ownerId,omitempty"`
+	Docs         string        `xml:"docs,omitempty"`
+}
+
+// UnmarshalXML unmarshals a Head.
+func (h *Head) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	type head Head
+	var elements struct {
+		XMLName xml.Name `xml:"head"`
+		head
+		DateCreated  *string `xml:"dateCreated"`
+		DateModified *string `xml:"dateModified"`
+	}
+	elements.head = head(*h)
+	if err := decoder.DecodeElement(&elements, &start); err != nil {
+		return err
+	}
+	*h = Head(elements.head)
+	h.XMLName = elements.XMLName
+	h.DateCreated = parseDate(elements.DateCreated)
+	h.DateModified = parseDate(elements.DateModified)
+	return nil
+}
+
+// parseDate parses s with rss2.ParseRSSTimeLenient. If this fails, s
+// is kept as Raw.
+func parseDate(s *string) *rss2.RSSTime {
+	if s == nil {
+		return nil
+	}
+	t, err := rss2.ParseRSSTimeLenient(*s)
+	if err != nil {
+		return &rss2.RSSTime{Raw: *s}
+	}
+	return &t
+}
+
+// Body represents the body element of an OPML document. It must
+// contain at least one Outline.
+type Body struct {
+	XMLName  xml.Name   `xml:"body"`
+	Outlines []*Outline `xml:"outline"`
+}
+
+// NewOPML creates a new OPML document with the given title and
+// outlines.
+func NewOPML(title string, outlines ...*Outline) *OPML {
+	return &OPML{
+		XMLName: xml.Name{Local: `opml`},
+		Version: `2.0`,
+		Head: &Head{
+			XMLName: xml.Name{Local: `head`},
+			Title:   title,
+		},
+		Body: &Body{
+			XMLName:  xml.Name{Local: `body`},
+			Outlines: outlines,
+		},
+	}
+}
+
+// Parse parses an OPML document. An error is returned if the document
+// is not well formed, its root element is not opml, its version is
+// neither "1.0" nor "2.0" or it contains no body.
+func Parse(data []byte) (*OPML, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseFile parses the OPML document stored at path. See Parse.
+func ParseFile(path string) (*OPML, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseReader(f)
+}
+
+// ParseReader parses an OPML document read from r. See Parse.
+func ParseReader(r io.Reader) (*OPML, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = rss2.CharsetReader
+	var doc OPML
+	if err := decoder.Decode(&doc); err != nil {
+		if _, ok := err.(xml.UnmarshalError); ok {
+			return nil, fmt.Errorf(`unexpected root element: %w`, err)
+		}
+		return nil, err
+	}
+	if doc.Version != `1.0` && doc.Version != `2.0` {
+		return nil, fmt.Errorf(`unsupported opml version '%s'`, doc.Version)
+	}
+	if doc.Body == nil {
+		return nil, fmt.Errorf(`opml element contains no body`)
+	}
+	return &doc, nil
+}
+
+// Render writes o as an indented XML document, including the XML
+// declaration, to w.
+func (o *OPML) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent(``, `  `)
+	if err := encoder.Encode(o); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Bytes returns the document written by Render.
+func (o *OPML) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := o.Render(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Feeds returns all outlines of o, that have an XMLURL, in document
+// order, regardless of the folders they are nested in.
+func (o *OPML) Feeds() []*Outline {
+	if o.Body == nil {
+		return nil
+	}
+	var feeds []*Outline
+	for _, outline := range o.Body.Outlines {
+		feeds = outline.appendFeeds(feeds)
+	}
+	return feeds
+}
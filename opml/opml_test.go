@@ -0,0 +1,120 @@
+package opml
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/codesoap/rss2"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Subscriptions</title>
+    <dateCreated>03 Feb 2022 09:39:21 +0000</dateCreated>
+  </head>
+  <body>
+    <outline text="News">
+      <outline text="Willie&#39;s Wiltshire News" type="rss" xmlUrl="https://willies-wilts.news/feed.xml" htmlUrl="https://willies-wilts.news"></outline>
+      <outline text="Local">
+        <outline text="Village" type="rss" xmlUrl="https://village.news/rss" custom="42"></outline>
+      </outline>
+    </outline>
+    <outline text="Podcast" type="rss" xmlUrl="https://pod.cast/feed"></outline>
+  </body>
+</opml>
+`
+
+func TestOPML(t *testing.T) {
+	willies, _ := NewFeed(`Willie's Wiltshire News`, `https://willies-wilts.news/feed.xml`)
+	willies.HTMLURL = `https://willies-wilts.news`
+	village, _ := NewFeed(`Village`, `https://village.news/rss`)
+	village.Attrs = []xml.Attr{{Name: xml.Name{Local: `custom`}, Value: `42`}}
+	local, _ := NewFolder(`Local`, village)
+	news, _ := NewFolder(`News`, willies, local)
+	podcast, _ := NewFeed(`Podcast`, `https://pod.cast/feed`)
+	expected := NewOPML(`Subscriptions`, news, podcast)
+	expected.Head.DateCreated = &rss2.RSSTime{Time: time.Date(2022, 2, 3, 9, 39, 21, 0, time.UTC)}
+
+	doc, err := Parse([]byte(testDocument))
+	if err != nil {
+		t.Fatal("Failed to parse:", err)
+	}
+	if diff := cmp.Diff(expected, doc); diff != "" {
+		t.Errorf("OPML parsing mismatch (-want +got):\n%s", diff)
+	}
+	out, err := expected.Bytes()
+	if err != nil {
+		t.Fatal("Failed to render:", err)
+	}
+	if diff := cmp.Diff(testDocument, string(out)); diff != "" {
+		t.Errorf("OPML rendering mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*Outline{willies, village, podcast}, doc.Feeds()); diff != "" {
+		t.Errorf("Feeds mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		`<rss version="2.0"></rss>`,
+		`<opml version="3.0"><body></body></opml>`,
+		`<opml version="2.0"><head></head></opml>`,
+		`<opml version="2.0"><body>`,
+	} {
+		if _, err := Parse([]byte(input)); err == nil {
+			t.Errorf("Expected error for '%s'", input)
+		}
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	channel, _ := rss2.NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	channel.Language = `en`
+	if _, err := FromChannel(channel, ``); err == nil {
+		t.Errorf("Expected error for channel without feed URL")
+	}
+	self, _ := rss2.NewSelfLink(`https://foo.com/feed.xml`)
+	channel.AtomLinks = []*rss2.AtomLink{self}
+	outline, err := FromChannel(channel, ``)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Outline{
+		XMLName:     xml.Name{Local: `outline`},
+		Text:        `Channel title`,
+		Type:        TypeRSS,
+		Title:       `Channel title`,
+		XMLURL:      `https://foo.com/feed.xml`,
+		HTMLURL:     `https://foo.com/`,
+		Description: `Channel description`,
+		Language:    `en`,
+		Version:     `RSS2`,
+	}
+	if diff := cmp.Diff(expected, outline); diff != "" {
+		t.Errorf("Outline mismatch (-want +got):\n%s", diff)
+	}
+	if outline, _ = FromChannel(channel, `https://foo.com/other.xml`); outline.XMLURL != `https://foo.com/other.xml` {
+		t.Errorf("Passed feed URL was not used")
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	doc, err := Parse([]byte(`<opml version="2.0"><head>
+<dateCreated>2022-02-03T09:39:21Z</dateCreated>
+<dateModified>last tuesday</dateModified>
+</head><body></body></opml>`))
+	if err != nil {
+		t.Fatal("Failed to parse:", err)
+	}
+	expected := &rss2.RSSTime{Time: time.Date(2022, 2, 3, 9, 39, 21, 0, time.UTC)}
+	if diff := cmp.Diff(expected, doc.Head.DateCreated); diff != "" {
+		t.Errorf("DateCreated mismatch (-want +got):\n%s", diff)
+	}
+	expected = &rss2.RSSTime{Raw: `last tuesday`}
+	if diff := cmp.Diff(expected, doc.Head.DateModified); diff != "" {
+		t.Errorf("DateModified mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/codesoap/rss2"
+)
+
+// Common values of Outline.Type.
+const (
+	TypeRSS     = `rss`
+	TypeLink    = `link`
+	TypeInclude = `include`
+)
+
+// Outline represents an outline element. Text must be present. Outlines
+// of Type "rss" represent feed subscriptions and must have an XMLURL.
+// Outlines without a Type, that contain other outlines, are commonly
+// used as folders. Attributes not known to this package are kept in
+// Attrs.
+type Outline struct {
+	XMLName      xml.Name   `xml:"outline"`
+	Text         string     `xml:"text,attr"`
+	Type         string     `xml:"type,attr,omitempty"`
+	Title        string     `xml:"title,attr,omitempty"`
+	XMLURL       string     `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL      string     `xml:"htmlUrl,attr,omitempty"`
+	Description  string     `xml:"description,attr,omitempty"`
+	Language     string     `xml:"language,attr,omitempty"`
+	Version      string     `xml:"version,attr,omitempty"`
+	URL          string     `xml:"url,attr,omitempty"`
+	Category     string     `xml:"category,attr,omitempty"`
+	Created      string     `xml:"created,attr,omitempty"`
+	IsComment    bool       `xml:"isComment,attr,omitempty"`
+	IsBreakpoint bool       `xml:"isBreakpoint,attr,omitempty"`
+	Attrs        []xml.Attr `xml:",any,attr"`
+	Outlines     []*Outline `xml:"outline"`
+}
+
+// NewOutline creates a new Outline.
+func NewOutline(text string) (*Outline, error) {
+	if len(text) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewOutline()`)
+	}
+	return &Outline{
+		XMLName: xml.Name{Local: `outline`},
+		Text:    text,
+	}, nil
+}
+
+// NewFolder creates a new Outline, that contains the given outlines.
+func NewFolder(text string, outlines ...*Outline) (*Outline, error) {
+	folder, err := NewOutline(text)
+	if err != nil {
+		return nil, err
+	}
+	folder.Outlines = outlines
+	return folder, nil
+}
+
+// NewFeed creates a new Outline of Type "rss" for the feed at xmlURL.
+func NewFeed(text, xmlURL string) (*Outline, error) {
+	if len(text) == 0 || len(xmlURL) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewFeed()`)
+	}
+	return &Outline{
+		XMLName: xml.Name{Local: `outline`},
+		Text:    text,
+		Type:    TypeRSS,
+		XMLURL:  xmlURL,
+	}, nil
+}
+
+// FromChannel creates a new Outline of Type "rss" for ch. Its Text and
+// Title are taken from the channel's title, HTMLURL from its link and
+// Description and Language from the channel as well. xmlURL may be
+// empty, if ch has an AtomLink with the Rel "self", which is used
+// instead.
+func FromChannel(ch *rss2.Channel, xmlURL string) (*Outline, error) {
+	if len(xmlURL) == 0 {
+		if self := ch.AtomLink(rss2.RelSelf); self != nil {
+			xmlURL = self.Href
+		}
+	}
+	if len(xmlURL) == 0 {
+		return nil, fmt.Errorf(`no feed URL passed to FromChannel() or found in channel`)
+	}
+	outline, err := NewFeed(ch.Title, xmlURL)
+	if err != nil {
+		return nil, err
+	}
+	outline.Title = ch.Title
+	outline.HTMLURL = ch.Link
+	outline.Description = ch.Description
+	outline.Language = ch.Language
+	outline.Version = `RSS2`
+	return outline, nil
+}
+
+// IsFeed reports whether o represents a feed subscription.
+func (o *Outline) IsFeed() bool {
+	return len(o.XMLURL) > 0
+}
+
+func (o *Outline) appendFeeds(feeds []*Outline) []*Outline {
+	if o.IsFeed() {
+		feeds = append(feeds, o)
+	}
+	for _, outline := range o.Outlines {
+		feeds = outline.appendFeeds(feeds)
+	}
+	return feeds
+}
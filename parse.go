@@ -0,0 +1,216 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ParseOptions configures how documents are parsed. The zero value
+// parses strictly, like Parse does.
+type ParseOptions struct {
+	// LenientTime makes date elements accept the formats understood by
+	// ParseRSSTimeLenient instead of only those understood by
+	// ParseRSSTime.
+	LenientTime bool
+
+	// KeepInvalidDates prevents dates that cannot be parsed from
+	// failing the whole document. Instead they are stored in
+	// RSSTime.Raw and reported as warnings. See Warnings.
+	KeepInvalidDates bool
+
+	// The following limits protect against documents from untrusted
+	// sources, that would otherwise consume excessive resources. A
+	// LimitError is returned, if a document exceeds one of them. Zero
+	// means no limit.
+	//
+	// MaxBytes limits the size of the document in bytes. MaxDepth
+	// limits the nesting depth of elements and MaxAttrs the number of
+	// attributes per element. MaxItems limits the number of items or
+	// entries and MaxDescriptionLength the length of descriptions in
+	// bytes. For JSON Feed documents, only MaxBytes is checked while
+	// reading, MaxItems and MaxDescriptionLength after decoding and the
+	// others not at all.
+	MaxBytes             int64
+	MaxDepth             int
+	MaxAttrs             int
+	MaxItems             int
+	MaxDescriptionLength int
+}
+
+// Warnings is returned together with the parsed RSS by the parse
+// functions of ParseOptions, if non-fatal problems were encountered.
+// The returned RSS is complete in that case.
+type Warnings []error
+
+func (w Warnings) Error() string {
+	msgs := make([]string, len(w))
+	for i, err := range w {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, `; `)
+}
+
+// decodeStates maps the decoders used by ParseOptions to their state,
+// so that the UnmarshalXML methods of this package can respect the
+// options.
+var decodeStates sync.Map
+
+type decodeState struct {
+	opts     ParseOptions
+	warnings Warnings
+}
+
+func (s *decodeState) warn(err error) {
+	s.warnings = append(s.warnings, err)
+}
+
+// stateOf returns the state of decoder. Decoders not created by
+// ParseOptions get the state of the zero ParseOptions.
+func stateOf(decoder *xml.Decoder) *decodeState {
+	if state, ok := decodeStates.Load(decoder); ok {
+		return state.(*decodeState)
+	}
+	return &decodeState{}
+}
+
+// ErrNoChannel is returned by the parse functions, if the rss element
+// of a document does not contain a channel element.
+var ErrNoChannel = errors.New(`rss element contains no channel`)
+
+// RootElementError is returned by the parse functions, if the root
+// element of a document is not the expected one.
+type RootElementError struct {
+	Name xml.Name
+}
+
+func (e *RootElementError) Error() string {
+	if e.Name.Local == `` {
+		return `document contains no root element`
+	}
+	return fmt.Sprintf(`unexpected root element '%s'`, e.Name.Local)
+}
+
+// VersionError is returned by the parse functions, if the version of a
+// document is not supported.
+type VersionError struct {
+	Version string
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf(`unsupported rss version '%s'`, e.Version)
+}
+
+// Parse parses an RSS 2.0 document. Documents of RSS 0.91 to 0.94 and
+// the RDF based RSS 0.90 and 1.0 are upgraded to RSS 2.0; see
+// RSS.SourceVersion. An error is returned if the document is not well
+// formed, its root element is neither rss nor rdf:RDF, its version is
+// not supported or it contains no channel.
+func Parse(data []byte) (*RSS, error) {
+	return ParseOptions{}.Parse(data)
+}
+
+// ParseFile parses the RSS 2.0 document stored at path. See Parse.
+func ParseFile(path string) (*RSS, error) {
+	return ParseOptions{}.ParseFile(path)
+}
+
+// ParseReader parses an RSS 2.0 document read from r. See Parse.
+// Documents that are not UTF-8 encoded are converted, if their encoding
+// is supported by CharsetReader.
+func ParseReader(r io.Reader) (*RSS, error) {
+	return ParseOptions{}.ParseReader(r)
+}
+
+// Parse is like the package level Parse, but respects o. If o allows
+// for non-fatal problems, they are returned as Warnings together with
+// the parsed RSS.
+func (o ParseOptions) Parse(data []byte) (*RSS, error) {
+	return o.ParseReader(bytes.NewReader(data))
+}
+
+// ParseFile is like the package level ParseFile, but respects o.
+func (o ParseOptions) ParseFile(path string) (*RSS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return o.ParseReader(f)
+}
+
+// ParseReader is like the package level ParseReader, but respects o.
+func (o ParseOptions) ParseReader(r io.Reader) (*RSS, error) {
+	decoder := o.newDecoder(r)
+	state := &decodeState{opts: o}
+	decodeStates.Store(decoder, state)
+	defer decodeStates.Delete(decoder)
+	start, err := rootElement(decoder)
+	if err != nil {
+		return nil, err
+	}
+	var rss *RSS
+	switch {
+	case start.Name.Local == `rss`:
+		rss = &RSS{}
+		if err = decoder.DecodeElement(rss, &start); err != nil {
+			return nil, err
+		}
+		if isLegacyVersion(rss.Version) {
+			rss.upgrade()
+		} else if rss.Version != `2.0` {
+			return nil, &VersionError{Version: rss.Version}
+		}
+	case start.Name == xml.Name{Space: rdfNamespace, Local: `RDF`}:
+		if rss, err = decodeRDF(decoder, start); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, &RootElementError{Name: start.Name}
+	}
+	if rss.Channel == nil {
+		return nil, ErrNoChannel
+	}
+	if len(state.warnings) > 0 {
+		return rss, state.warnings
+	}
+	return rss, nil
+}
+
+// newDecoder returns a decoder for r. Documents that are not UTF-8
+// encoded are converted, if their encoding is supported by
+// CharsetReader.
+func newDecoder(r io.Reader) *xml.Decoder {
+	r, converted := newUTF8Reader(r)
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = CharsetReader
+	if converted {
+		// The declared encoding is meaningless, since the input has
+		// already been converted to UTF-8.
+		decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+	}
+	return decoder
+}
+
+// rootElement reads tokens from decoder until the first start element
+// is found and returns it.
+func rootElement(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return xml.StartElement{}, &RootElementError{}
+		} else if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
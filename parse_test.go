@@ -0,0 +1,231 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range xmlToRSSTestCases {
+		parse, err := Parse([]byte(tc.Input))
+		if err != nil {
+			t.Errorf(err.Error())
+			continue
+		}
+		if diff := cmp.Diff(tc.Expected, *parse); diff != "" {
+			t.Errorf("RSS parsing mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `feed.xml`)
+	if err := os.WriteFile(path, []byte(xmlToRSSTestCases[0].Input), 0600); err != nil {
+		t.Fatal(err)
+	}
+	parse, err := ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(xmlToRSSTestCases[0].Expected, *parse); diff != "" {
+		t.Errorf("RSS parsing mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var rootErr *RootElementError
+	if _, err := Parse([]byte(`<feed></feed>`)); !errors.As(err, &rootErr) {
+		t.Errorf("Expected RootElementError for feed element, got '%v'", err)
+	} else if rootErr.Name.Local != `feed` {
+		t.Errorf("Expected root element 'feed', got '%s'", rootErr.Name.Local)
+	}
+	if _, err := Parse([]byte(`<?xml version="1.0"?>`)); !errors.As(err, &rootErr) {
+		t.Errorf("Expected RootElementError for empty document, got '%v'", err)
+	}
+	var versionErr *VersionError
+	_, err := Parse([]byte(`<rss version="3.0"><channel></channel></rss>`))
+	if !errors.As(err, &versionErr) {
+		t.Errorf("Expected VersionError, got '%v'", err)
+	} else if versionErr.Version != `3.0` {
+		t.Errorf("Expected version '3.0', got '%s'", versionErr.Version)
+	}
+	if _, err := Parse([]byte(`<rss version="2.0"></rss>`)); err != ErrNoChannel {
+		t.Errorf("Expected ErrNoChannel, got '%v'", err)
+	}
+	if _, err := Parse([]byte(`<rss version="2.0"><channel>`)); err == nil {
+		t.Errorf("Expected error for malformed document")
+	}
+}
+
+func TestParseLegacy(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<!DOCTYPE rss PUBLIC "-//Netscape Communications//DTD RSS 0.91//EN" "http://my.netscape.com/publish/formats/rss-0.91.dtd">
+<rss version="0.91">
+  <channel>
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+    <language>en-us</language>
+    <textinput>
+      <title>Search</title>
+      <description>Search the site</description>
+      <name>q</name>
+      <link>https://foo.com/search</link>
+    </textinput>
+    <skipHours><hour>24</hour><hour>1</hour></skipHours>
+    <item>
+      <title>Item 1</title>
+      <link>https://foo.com/1</link>
+    </item>
+  </channel>
+</rss>`
+	textInput, _ := NewTextInput(`Search`, `Search the site`, `q`, `https://foo.com/search`)
+	skipHours, _ := NewSkipHours([]int{0, 1})
+	item, _ := NewItem(`Item 1`, ``)
+	item.Link = `https://foo.com/1`
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	channel.Language = `en-us`
+	channel.TextInput = textInput
+	channel.SkipHours = skipHours
+	channel.Items = []*Item{item}
+	expected := NewRSS(channel)
+	expected.SourceVersion = `0.91`
+	parse, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, parse); diff != "" {
+		t.Errorf("RSS 0.91 parsing mismatch (-want +got):\n%s", diff)
+	}
+
+	input = `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+    xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="http://purl.org/rss/1.0/">
+  <channel rdf:about="https://foo.com/feed.rdf">
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <description>Channel description</description>
+    <image rdf:resource="https://foo.com/logo.png"/>
+    <items>
+      <rdf:Seq>
+        <rdf:li resource="https://foo.com/1"/>
+        <rdf:li resource="https://foo.com/2"/>
+      </rdf:Seq>
+    </items>
+  </channel>
+  <image rdf:about="https://foo.com/logo.png">
+    <title>Channel title</title>
+    <link>https://foo.com/</link>
+    <url>https://foo.com/logo.png</url>
+  </image>
+  <item rdf:about="https://foo.com/1">
+    <title>Item 1</title>
+    <link>https://foo.com/1</link>
+    <dc:creator>Jane Doe</dc:creator>
+  </item>
+  <item rdf:about="urn:foo:2">
+    <title>Item 2</title>
+    <link>https://foo.com/2</link>
+  </item>
+</rdf:RDF>`
+	image, _ := NewImage(`https://foo.com/logo.png`, `Channel title`, `https://foo.com/`)
+	item1, _ := NewItem(`Item 1`, ``)
+	item1.Link = `https://foo.com/1`
+	item1.GUID = &GUID{XMLName: xml.Name{Local: `guid`}, Value: `https://foo.com/1`, IsPermaLink: true}
+	item1.DC = &DublinCore{Creators: []string{`Jane Doe`}}
+	item2, _ := NewItem(`Item 2`, ``)
+	item2.Link = `https://foo.com/2`
+	item2.GUID, _ = NewGUID(`urn:foo:2`)
+	channel, _ = NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	channel.Image = image
+	channel.Items = []*Item{item1, item2}
+	expected = NewRSS(channel)
+	expected.SourceVersion = `1.0`
+	parse, err = Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, parse); diff != "" {
+		t.Errorf("RSS 1.0 parsing mismatch (-want +got):\n%s", diff)
+	}
+
+	input = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+    xmlns="http://my.netscape.com/rdf/simple/0.9/">
+  <channel><title>Channel title</title></channel>
+</rdf:RDF>`
+	if parse, err = Parse([]byte(input)); err != nil {
+		t.Fatal(err)
+	} else if parse.SourceVersion != `0.90` || parse.Channel.Title != `Channel title` {
+		t.Errorf("Unexpected RSS 0.90 parsing result %v", parse)
+	}
+	if _, err = Parse([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>`)); err != ErrNoChannel {
+		t.Errorf("Expected ErrNoChannel, got '%v'", err)
+	}
+}
+
+func TestParseLenientTime(t *testing.T) {
+	input := `<rss version="2.0"><channel><item>
+		<pubDate>2023-05-01T10:00:00Z</pubDate>
+	</item></channel></rss>`
+	if _, err := Parse([]byte(input)); err == nil {
+		t.Errorf("Expected error for ISO 8601 date in strict mode")
+	}
+	parse, err := ParseOptions{LenientTime: true}.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	if !parse.Channel.Items[0].PubDate.Time.Equal(expected) {
+		t.Errorf("Parsing yielded '%s'. Expected '%s'",
+			parse.Channel.Items[0].PubDate.Time, expected)
+	}
+}
+
+func TestParseKeepInvalidDates(t *testing.T) {
+	input := `<rss version="2.0"><channel>
+		<title>Channel title</title>
+		<link>foo.com</link>
+		<description>Channel description</description>
+		<item><title>Item 0</title><pubDate>yesterday</pubDate></item>
+		<item><title>Item 1</title><pubDate>03 Jun 2019 09:39:21 GMT</pubDate></item>
+	</channel></rss>`
+	if _, err := Parse([]byte(input)); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+	parse, err := ParseOptions{KeepInvalidDates: true}.Parse([]byte(input))
+	var warnings Warnings
+	if !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Fatalf("Expected one warning, got '%v'", err)
+	}
+	var dateErr *DateError
+	if !errors.As(warnings[0], &dateErr) || dateErr.Element != `pubDate` ||
+		dateErr.Value != `yesterday` {
+		t.Errorf("Unexpected warning '%v'", warnings[0])
+	}
+	if len(parse.Channel.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(parse.Channel.Items))
+	}
+	if diff := cmp.Diff(&RSSTime{Raw: `yesterday`}, parse.Channel.Items[0].PubDate); diff != "" {
+		t.Errorf("RSSTime mismatch (-want +got):\n%s", diff)
+	}
+	if parse.Channel.Items[1].PubDate.Time.IsZero() {
+		t.Errorf("Valid date was not parsed")
+	}
+	expected := ValidationErrors{{`channel/item[0]/pubDate`, `'yesterday' is not a valid date`}}
+	if diff := cmp.Diff(expected, parse.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+	out, err := xml.Marshal(parse.Channel.Items[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<item><title>Item 0</title><pubDate>yesterday</pubDate></item>`; string(out) != expected {
+		t.Errorf("Rendering yielded '%s'. Expected '%s'", out, expected)
+	}
+}
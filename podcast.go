@@ -0,0 +1,299 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// PodcastChannel holds the channel elements of the Podcasting 2.0
+// namespace. GUID should be a UUIDv5 identifying the podcast.
+type PodcastChannel struct {
+	GUID    string            `xml:"podcast:guid,omitempty"`
+	Locked  *PodcastLocked    `xml:"podcast:locked,omitempty"`
+	Funding []*PodcastFunding `xml:"podcast:funding,omitempty"`
+	Persons []*PodcastPerson  `xml:"podcast:person,omitempty"`
+	Value   *PodcastValue     `xml:"podcast:value,omitempty"`
+}
+
+// PodcastItem holds the item elements of the Podcasting 2.0 namespace.
+// AlternateEnclosures complement the Item's Enclosure with other
+// versions of the media file.
+type PodcastItem struct {
+	Transcripts         []*PodcastTranscript         `xml:"podcast:transcript,omitempty"`
+	Chapters            *PodcastChapters             `xml:"podcast:chapters,omitempty"`
+	Persons             []*PodcastPerson             `xml:"podcast:person,omitempty"`
+	Value               *PodcastValue                `xml:"podcast:value,omitempty"`
+	AlternateEnclosures []*PodcastAlternateEnclosure `xml:"podcast:alternateEnclosure,omitempty"`
+}
+
+// PodcastLocked represents a podcast:locked element. Value must be
+// "yes" or "no". Owner is optional.
+type PodcastLocked struct {
+	XMLName xml.Name `xml:"podcast:locked"`
+	Value   string   `xml:",chardata"`
+	Owner   string   `xml:"owner,attr,omitempty"`
+}
+
+// PodcastFunding represents a podcast:funding element. URL must be
+// present.
+type PodcastFunding struct {
+	XMLName xml.Name `xml:"podcast:funding"`
+	Value   string   `xml:",chardata"`
+	URL     string   `xml:"url,attr"`
+}
+
+// PodcastPerson represents a podcast:person element. Name must be
+// present.
+type PodcastPerson struct {
+	XMLName xml.Name `xml:"podcast:person"`
+	Name    string   `xml:",chardata"`
+	Role    string   `xml:"role,attr,omitempty"`
+	Group   string   `xml:"group,attr,omitempty"`
+	Img     string   `xml:"img,attr,omitempty"`
+	Href    string   `xml:"href,attr,omitempty"`
+}
+
+// PodcastTranscript represents a podcast:transcript element. URL and
+// Type must be present.
+type PodcastTranscript struct {
+	XMLName  xml.Name `xml:"podcast:transcript"`
+	URL      string   `xml:"url,attr"`
+	Type     string   `xml:"type,attr"`
+	Language string   `xml:"language,attr,omitempty"`
+	Rel      string   `xml:"rel,attr,omitempty"`
+}
+
+// PodcastChapters represents a podcast:chapters element. URL and Type
+// must be present.
+type PodcastChapters struct {
+	XMLName xml.Name `xml:"podcast:chapters"`
+	URL     string   `xml:"url,attr"`
+	Type    string   `xml:"type,attr"`
+}
+
+// PodcastValue represents a podcast:value element. Type and Method must
+// be present.
+type PodcastValue struct {
+	XMLName    xml.Name                 `xml:"podcast:value"`
+	Type       string                   `xml:"type,attr"`
+	Method     string                   `xml:"method,attr"`
+	Suggested  string                   `xml:"suggested,attr,omitempty"`
+	Recipients []*PodcastValueRecipient `xml:"podcast:valueRecipient"`
+}
+
+// PodcastValueRecipient represents a podcast:valueRecipient element.
+// Type, Address and Split must be present.
+type PodcastValueRecipient struct {
+	XMLName     xml.Name `xml:"podcast:valueRecipient"`
+	Name        string   `xml:"name,attr,omitempty"`
+	CustomKey   string   `xml:"customKey,attr,omitempty"`
+	CustomValue string   `xml:"customValue,attr,omitempty"`
+	Type        string   `xml:"type,attr"`
+	Address     string   `xml:"address,attr"`
+	Split       int      `xml:"split,attr"`
+	Fee         bool     `xml:"fee,attr,omitempty"`
+}
+
+// PodcastAlternateEnclosure represents a podcast:alternateEnclosure
+// element. Type and at least one of Sources must be present.
+type PodcastAlternateEnclosure struct {
+	XMLName   xml.Name          `xml:"podcast:alternateEnclosure"`
+	Type      string            `xml:"type,attr"`
+	Length    int               `xml:"length,attr,omitempty"`
+	Bitrate   float64           `xml:"bitrate,attr,omitempty"`
+	Height    int               `xml:"height,attr,omitempty"`
+	Lang      string            `xml:"lang,attr,omitempty"`
+	Title     string            `xml:"title,attr,omitempty"`
+	Rel       string            `xml:"rel,attr,omitempty"`
+	Codecs    string            `xml:"codecs,attr,omitempty"`
+	Default   bool              `xml:"default,attr,omitempty"`
+	Sources   []*PodcastSource  `xml:"podcast:source"`
+	Integrity *PodcastIntegrity `xml:"podcast:integrity,omitempty"`
+}
+
+// PodcastSource represents a podcast:source element of a
+// PodcastAlternateEnclosure. URI must be present.
+type PodcastSource struct {
+	XMLName     xml.Name `xml:"podcast:source"`
+	URI         string   `xml:"uri,attr"`
+	ContentType string   `xml:"contentType,attr,omitempty"`
+}
+
+// PodcastIntegrity represents a podcast:integrity element of a
+// PodcastAlternateEnclosure. Type must be "sri" or "pgp-signature".
+type PodcastIntegrity struct {
+	XMLName xml.Name `xml:"podcast:integrity"`
+	Type    string   `xml:"type,attr"`
+	Value   string   `xml:"value,attr"`
+}
+
+// NewPodcastLocked creates a new PodcastLocked element. owner may be
+// empty.
+func NewPodcastLocked(locked bool, owner string) *PodcastLocked {
+	value := `no`
+	if locked {
+		value = `yes`
+	}
+	return &PodcastLocked{
+		XMLName: xml.Name{Local: `podcast:locked`},
+		Value:   value,
+		Owner:   owner,
+	}
+}
+
+// NewPodcastFunding creates a new PodcastFunding element. text may be
+// empty.
+func NewPodcastFunding(url, text string) (*PodcastFunding, error) {
+	if len(url) == 0 {
+		return nil, fmt.Errorf(`empty url passed to NewPodcastFunding()`)
+	}
+	return &PodcastFunding{
+		XMLName: xml.Name{Local: `podcast:funding`},
+		Value:   text,
+		URL:     url,
+	}, nil
+}
+
+// NewPodcastPerson creates a new PodcastPerson element.
+func NewPodcastPerson(name string) (*PodcastPerson, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastPerson()`)
+	}
+	return &PodcastPerson{
+		XMLName: xml.Name{Local: `podcast:person`},
+		Name:    name,
+	}, nil
+}
+
+// NewPodcastTranscript creates a new PodcastTranscript element.
+func NewPodcastTranscript(url, t string) (*PodcastTranscript, error) {
+	if len(url) == 0 || len(t) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastTranscript()`)
+	}
+	return &PodcastTranscript{
+		XMLName: xml.Name{Local: `podcast:transcript`},
+		URL:     url,
+		Type:    t,
+	}, nil
+}
+
+// NewPodcastChapters creates a new PodcastChapters element with the
+// type "application/json+chapters".
+func NewPodcastChapters(url string) (*PodcastChapters, error) {
+	if len(url) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastChapters()`)
+	}
+	return &PodcastChapters{
+		XMLName: xml.Name{Local: `podcast:chapters`},
+		URL:     url,
+		Type:    `application/json+chapters`,
+	}, nil
+}
+
+// NewPodcastValue creates a new PodcastValue element.
+func NewPodcastValue(t, method string, recipients []*PodcastValueRecipient) (*PodcastValue, error) {
+	if len(t) == 0 || len(method) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastValue()`)
+	}
+	return &PodcastValue{
+		XMLName:    xml.Name{Local: `podcast:value`},
+		Type:       t,
+		Method:     method,
+		Recipients: recipients,
+	}, nil
+}
+
+// NewPodcastValueRecipient creates a new PodcastValueRecipient element.
+func NewPodcastValueRecipient(t, address string, split int) (*PodcastValueRecipient, error) {
+	if len(t) == 0 || len(address) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastValueRecipient()`)
+	}
+	return &PodcastValueRecipient{
+		XMLName: xml.Name{Local: `podcast:valueRecipient`},
+		Type:    t,
+		Address: address,
+		Split:   split,
+	}, nil
+}
+
+// NewPodcastAlternateEnclosure creates a new PodcastAlternateEnclosure
+// element with a single source.
+func NewPodcastAlternateEnclosure(t, uri string) (*PodcastAlternateEnclosure, error) {
+	if len(t) == 0 || len(uri) == 0 {
+		return nil, fmt.Errorf(`empty string passed to NewPodcastAlternateEnclosure()`)
+	}
+	return &PodcastAlternateEnclosure{
+		XMLName: xml.Name{Local: `podcast:alternateEnclosure`},
+		Type:    t,
+		Sources: []*PodcastSource{{
+			XMLName: xml.Name{Local: `podcast:source`},
+			URI:     uri,
+		}},
+	}, nil
+}
+
+func (p *PodcastChannel) validate(path string) (errs ValidationErrors) {
+	if p.Locked != nil {
+		errs.requireOneOf(path+`/podcast:locked`, p.Locked.Value, `yes`, `no`)
+	}
+	for i, funding := range p.Funding {
+		errs.requireNonEmpty(indexPath(path, `podcast:funding`, i)+`@url`, funding.URL)
+	}
+	for i, person := range p.Persons {
+		errs.requireNonEmpty(indexPath(path, `podcast:person`, i), person.Name)
+	}
+	if p.Value != nil {
+		errs = append(errs, p.Value.validate(path+`/podcast:value`)...)
+	}
+	return
+}
+
+func (p *PodcastItem) validate(path string) (errs ValidationErrors) {
+	for i, transcript := range p.Transcripts {
+		transcriptPath := indexPath(path, `podcast:transcript`, i)
+		errs.requireNonEmpty(transcriptPath+`@url`, transcript.URL)
+		errs.requireNonEmpty(transcriptPath+`@type`, transcript.Type)
+	}
+	if p.Chapters != nil {
+		errs.requireNonEmpty(path+`/podcast:chapters@url`, p.Chapters.URL)
+		errs.requireNonEmpty(path+`/podcast:chapters@type`, p.Chapters.Type)
+	}
+	for i, person := range p.Persons {
+		errs.requireNonEmpty(indexPath(path, `podcast:person`, i), person.Name)
+	}
+	if p.Value != nil {
+		errs = append(errs, p.Value.validate(path+`/podcast:value`)...)
+	}
+	for i, enclosure := range p.AlternateEnclosures {
+		errs = append(errs, enclosure.validate(indexPath(path, `podcast:alternateEnclosure`, i))...)
+	}
+	return
+}
+
+func (v *PodcastValue) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@type`, v.Type)
+	errs.requireNonEmpty(path+`@method`, v.Method)
+	for i, recipient := range v.Recipients {
+		recipientPath := indexPath(path, `podcast:valueRecipient`, i)
+		errs.requireNonEmpty(recipientPath+`@type`, recipient.Type)
+		errs.requireNonEmpty(recipientPath+`@address`, recipient.Address)
+		if recipient.Split < 0 {
+			errs.add(recipientPath+`@split`, `must not be negative`)
+		}
+	}
+	return
+}
+
+func (a *PodcastAlternateEnclosure) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@type`, a.Type)
+	if len(a.Sources) == 0 {
+		errs.add(path+`/podcast:source`, `must be present`)
+	}
+	for i, source := range a.Sources {
+		errs.requireNonEmpty(indexPath(path, `podcast:source`, i)+`@uri`, source.URI)
+	}
+	if a.Integrity != nil {
+		errs.requireOneOf(path+`/podcast:integrity@type`, a.Integrity.Type, `sri`, `pgp-signature`)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// registeredTypes maps the prefixed names of registered extension
+// elements to their types and registeredNames does the reverse.
+var (
+	registeredTypes = make(map[string]reflect.Type)
+	registeredNames = make(map[reflect.Type]string)
+)
+
+// RegisterExtension registers the type of v for the elements with the
+// given namespace and local name. Elements of Channels and Items
+// matching them are then decoded into a new value of that type, which
+// is stored in Element.Object. When marshalling, Element.Object is
+// encoded in place of the Element and the namespace is declared on the
+// rss element with the given prefix.
+//
+// v may be a pointer; the type it points to is registered then. An
+// XMLName field of the type must be untagged or tagged with the
+// prefixed name, e.g. "slash:comments". Each type can only be
+// registered once and the namespaces supported by this package cannot
+// get additional prefixes.
+//
+// RegisterExtension is meant to be called during initialization, e.g.
+// from an init function. It must not be called concurrently with
+// parsing or rendering.
+func RegisterExtension(namespace, prefix, local string, v interface{}) error {
+	if len(namespace) == 0 || len(prefix) == 0 || len(local) == 0 {
+		return fmt.Errorf(`empty string passed to RegisterExtension()`)
+	}
+	typ := reflect.TypeOf(v)
+	if typ == nil {
+		return fmt.Errorf(`nil passed to RegisterExtension()`)
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if known, ok := namespacePrefixes[namespace]; ok && known != prefix {
+		return fmt.Errorf(`namespace '%s' already uses prefix '%s'`, namespace, known)
+	}
+	for known, knownPrefix := range namespacePrefixes {
+		if knownPrefix == prefix && known != namespace {
+			return fmt.Errorf(`prefix '%s' already used by namespace '%s'`, prefix, known)
+		}
+	}
+	name := prefix + `:` + local
+	if _, ok := registeredTypes[name]; ok {
+		return fmt.Errorf(`element '%s' already registered`, name)
+	}
+	if _, ok := registeredNames[typ]; ok {
+		return fmt.Errorf(`type %s already registered`, typ)
+	}
+	namespacePrefixes[namespace] = prefix
+	registeredTypes[name] = typ
+	registeredNames[typ] = name
+	return nil
+}
+
+// NewExtension creates a new Element holding v, whose type must have been
+// registered with RegisterExtension.
+func NewExtension(v interface{}) (*Element, error) {
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	name, ok := registeredNames[typ]
+	if !ok {
+		return nil, fmt.Errorf(`type %s passed to NewExtension() is not registered`, typ)
+	}
+	return &Element{XMLName: xml.Name{Local: name}, Object: v}, nil
+}
@@ -0,0 +1,89 @@
+package rss2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// RenderOption configures the rendering of documents, e.g. by Render
+// and Bytes.
+type RenderOption func(*renderConfig)
+
+type renderConfig struct {
+	prefix      string
+	indent      string
+	stylesheets []stylesheet
+}
+
+type stylesheet struct {
+	href string
+	typ  string
+}
+
+// WithIndent makes the output indented like xml.MarshalIndent does.
+func WithIndent(prefix, indent string) RenderOption {
+	return func(c *renderConfig) {
+		c.prefix = prefix
+		c.indent = indent
+	}
+}
+
+// WithStylesheet adds an xml-stylesheet processing instruction, which
+// browsers use to display the feed. typ is the MIME type of the
+// stylesheet, usually "text/xsl" or "text/css". The option can be
+// given multiple times.
+func WithStylesheet(href, typ string) RenderOption {
+	return func(c *renderConfig) {
+		c.stylesheets = append(c.stylesheets, stylesheet{href: href, typ: typ})
+	}
+}
+
+// Render writes r as an XML document, including the XML declaration,
+// to w. r is validated first and nothing is written, if Validate
+// returns an error.
+func (r *RSS) Render(w io.Writer, opts ...RenderOption) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return render(w, r, opts)
+}
+
+// render writes v as an XML document to w.
+func render(w io.Writer, v interface{}, opts []RenderOption) error {
+	var config renderConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	for _, s := range config.stylesheets {
+		fmt.Fprintf(&buf, `<?xml-stylesheet type="%s" href="%s"?>`,
+			escapeAttr(s.typ), escapeAttr(s.href))
+		buf.WriteByte('\n')
+	}
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent(config.prefix, config.indent)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Bytes returns the document written by Render.
+func (r *RSS) Bytes(opts ...RenderOption) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.Render(&buf, opts...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func escapeAttr(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
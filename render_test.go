@@ -0,0 +1,66 @@
+package rss2
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRender(t *testing.T) {
+	item, err := NewItem(`Item 1`, ``)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	channel, err := NewChannel(`Channel title`, `foo.com`, `Channel description`)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	channel.Items = []*Item{item}
+	rss := NewRSS(channel)
+
+	out, err := rss.Bytes()
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Channel title</title><link>foo.com</link><description>Channel description</description><item><title>Item 1</title></item></channel></rss>
+`
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Errorf("RSS rendering mismatch (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	err = rss.Render(&buf, WithIndent(``, `  `),
+		WithStylesheet(`/feed.xsl?a=1&b=2`, `text/xsl`))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	expected = `<?xml version="1.0" encoding="UTF-8"?>
+<?xml-stylesheet type="text/xsl" href="/feed.xsl?a=1&amp;b=2"?>
+<rss version="2.0">
+  <channel>
+    <title>Channel title</title>
+    <link>foo.com</link>
+    <description>Channel description</description>
+    <item>
+      <title>Item 1</title>
+    </item>
+  </channel>
+</rss>
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("RSS rendering mismatch (-want +got):\n%s", diff)
+	}
+
+	channel.Title = ``
+	buf.Reset()
+	var errs ValidationErrors
+	if err = rss.Render(&buf); !errors.As(err, &errs) {
+		t.Errorf("Expected ValidationErrors, got '%v'", err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Invalid RSS was rendered: '%s'", buf.String())
+	}
+}
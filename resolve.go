@@ -0,0 +1,238 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// ResolveURLs turns the relative URLs of r into absolute ones, so that
+// they keep working outside the publisher's site. This includes
+// protocol-relative URLs like "//foo.com/feed.xml".
+//
+// The URLs of the channel are resolved against its xml:base attribute
+// or, if there is none, Channel.Link. Those of an item are resolved
+// against the item's xml:base attribute or, if there is none, the base
+// of the channel. xml:base attributes are relative to the base of their
+// parent and are resolved as well. Resolved are:
+//   - Link and Docs of the channel, Image.URL, Image.Link and
+//     TextInput.Link
+//   - Link, Comments, Source.URL and Enclosure.URL of items and the
+//     GUIDs, that are permalinks; a guid without isPermaLink attribute
+//     is resolved only if it looks like a relative URL, e.g. "posts/1",
+//     so that opaque ids like "12345" are kept
+//   - the AtomLinks of the channel and the items
+//   - the href, src and cite attributes in the HTML of Description and
+//     Content of items
+//
+// The HTML is not sanitized otherwise; see SanitizedDescription.
+func (r *RSS) ResolveURLs() {
+	if r.Channel == nil {
+		return
+	}
+	docBase := resolveXMLBase(r.Attrs, nil)
+	ch := r.Channel
+	chBase := resolveXMLBase(ch.Attrs, docBase)
+	if chBase == nil {
+		chBase = docBase
+	}
+	ch.Link = resolveURL(ch.Link, chBase)
+	if chBase == nil {
+		chBase = absoluteURL(ch.Link)
+	}
+	ch.Docs = resolveURL(ch.Docs, chBase)
+	if ch.Image != nil {
+		ch.Image.URL = resolveURL(ch.Image.URL, chBase)
+		ch.Image.Link = resolveURL(ch.Image.Link, chBase)
+	}
+	if ch.TextInput != nil {
+		ch.TextInput.Link = resolveURL(ch.TextInput.Link, chBase)
+	}
+	resolveAtomLinks(ch.AtomLinks, chBase)
+	for _, it := range ch.Items {
+		it.resolveURLs(chBase)
+	}
+}
+
+func (it *Item) resolveURLs(chBase *url.URL) {
+	base := resolveXMLBase(it.Attrs, chBase)
+	if base == nil {
+		base = chBase
+	}
+	it.Link = resolveURL(it.Link, base)
+	it.Comments = resolveURL(it.Comments, base)
+	if it.Source != nil {
+		it.Source.URL = resolveURL(it.Source.URL, base)
+	}
+	if it.Enclosure != nil {
+		it.Enclosure.URL = resolveURL(it.Enclosure.URL, base)
+	}
+	if it.GUID != nil && (it.GUID.IsPermaLink ||
+		it.GUID.implicit && isRelativeURLPath(it.GUID.Value)) {
+		it.GUID.Value = resolveURL(it.GUID.Value, base)
+	}
+	resolveAtomLinks(it.AtomLinks, base)
+	it.Description = resolveHTML(it.Description, base)
+	if it.Content != nil {
+		it.Content.Value = resolveHTML(it.Content.Value, base)
+	}
+}
+
+func resolveAtomLinks(links []*AtomLink, base *url.URL) {
+	for _, link := range links {
+		link.Href = resolveURL(link.Href, base)
+	}
+}
+
+// resolveHTML resolves the URLs of the urlAttrs in the HTML fragment s
+// against base. Only tags containing relative URLs are rewritten; the
+// rest of s is kept as is.
+func resolveHTML(s string, base *url.URL) string {
+	if base == nil || strings.IndexByte(s, '<') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		n := 1
+		switch {
+		case strings.HasPrefix(s, `<!--`):
+			if end := strings.Index(s[4:], `-->`); end < 0 {
+				n = len(s)
+			} else {
+				n = 4 + end + 3
+			}
+		case strings.HasPrefix(s, `<![CDATA[`):
+			if end := strings.Index(s, `]]>`); end < 0 {
+				n = len(s)
+			} else {
+				n = end + 3
+			}
+		case strings.HasPrefix(s, `<!`), strings.HasPrefix(s, `<?`):
+			if end := strings.IndexByte(s, '>'); end < 0 {
+				n = len(s)
+			} else {
+				n = end + 1
+			}
+		case len(s) > 1 && isASCIILetter(s[1]):
+			token, tagLen := parseHTMLTag(s)
+			if tagLen == 0 {
+				n = len(s)
+				break
+			}
+			n = tagLen
+			if rawTextElements[token.data] {
+				n += indexEndTag(s[n:], token.data)
+			}
+			if tag, ok := resolveHTMLTag(token, s[:tagLen], base); ok {
+				b.WriteString(tag)
+				b.WriteString(s[tagLen:n])
+				s = s[n:]
+				continue
+			}
+		}
+		b.WriteString(s[:n])
+		s = s[n:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// resolveHTMLTag writes the start tag token anew with the URLs of its
+// urlAttrs resolved against base. It reports false, if no URL changed.
+// raw is the tag as found in the document.
+func resolveHTMLTag(token htmlToken, raw string, base *url.URL) (string, bool) {
+	changed := false
+	for i, attr := range token.attrs {
+		if _, ok := urlAttrs[attr.name]; !ok {
+			continue
+		}
+		if resolved := resolveURL(attr.value, base); resolved != attr.value {
+			token.attrs[i].value = resolved
+			changed = true
+		}
+	}
+	if !changed {
+		return ``, false
+	}
+	var b strings.Builder
+	b.WriteString(`<` + token.data)
+	for _, attr := range token.attrs {
+		b.WriteString(` ` + attr.name + `="` + html.EscapeString(attr.value) + `"`)
+	}
+	if strings.HasSuffix(raw, `/>`) {
+		b.WriteString(` /`)
+	}
+	b.WriteString(`>`)
+	return b.String(), true
+}
+
+// baseURL returns the absolute URL, that relative URLs of ch are
+// relative to, or nil if it is unknown.
+func (ch *Channel) baseURL() *url.URL {
+	if base := xmlBase(ch.Attrs, nil); base != nil {
+		return base
+	}
+	return absoluteURL(ch.Link)
+}
+
+// xmlBase returns the value of the xml:base attribute in attrs resolved
+// against base, if the result is absolute.
+func xmlBase(attrs []xml.Attr, base *url.URL) *url.URL {
+	for _, attr := range attrs {
+		if attr.Name == (xml.Name{Local: `xml:base`}) {
+			return absoluteURL(resolveURL(attr.Value, base))
+		}
+	}
+	return nil
+}
+
+// resolveXMLBase is like xmlBase, but also stores the resolved value in
+// attrs.
+func resolveXMLBase(attrs []xml.Attr, base *url.URL) *url.URL {
+	for i, attr := range attrs {
+		if attr.Name == (xml.Name{Local: `xml:base`}) {
+			attrs[i].Value = resolveURL(attr.Value, base)
+		}
+	}
+	return xmlBase(attrs, nil)
+}
+
+// resolveURL resolves s against base. s is returned unchanged, if it is
+// empty, absolute or cannot be parsed or if base is nil.
+func resolveURL(s string, base *url.URL) string {
+	trimmed := strings.TrimSpace(s)
+	if base == nil || len(trimmed) == 0 {
+		return s
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil || u.IsAbs() {
+		return s
+	}
+	return base.ResolveReference(u).String()
+}
+
+// absoluteURL parses s and returns it, if it is an absolute URL.
+func absoluteURL(s string) *url.URL {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
+
+// isRelativeURLPath reports whether s is a relative URL with a slash in
+// its path.
+func isRelativeURLPath(s string) bool {
+	if strings.ContainsAny(s, " \t\r\n") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && !u.IsAbs() && strings.Contains(u.Path, `/`)
+}
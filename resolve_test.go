@@ -0,0 +1,131 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResolveURLs(t *testing.T) {
+	input := `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+  <title>Channel title</title>
+  <link>/news/</link>
+  <description>Channel description</description>
+  <docs>../rss</docs>
+  <image><url>//cdn.foo.com/logo.png</url><title>Logo</title><link>./</link></image>
+  <textInput><title>Search</title><description>Search</description><name>q</name><link>search</link></textInput>
+  <atom:link href="feed.xml" rel="self"/>
+  <item>
+    <title>Item 1</title>
+    <link>1.html</link>
+    <comments>1.html#comments</comments>
+    <enclosure url="1.mp3" length="1" type="audio/mpeg"/>
+    <guid isPermaLink="true">1.html</guid>
+    <source url="https://bar.com/rss">Bar</source>
+  </item>
+  <item xml:base="https://bar.com/posts/">
+    <title>Item 2</title>
+    <link>2.html</link>
+    <guid>2.html</guid>
+  </item>
+  <item>
+    <title>Item 3</title>
+    <guid isPermaLink="false">p/3</guid>
+  </item>
+  <item>
+    <title>Item 4</title>
+    <guid>p/4</guid>
+  </item>
+  <item>
+    <title>Item 5</title>
+    <guid>12345</guid>
+  </item>
+</channel></rss>`
+	rss, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without xml:base, there is no absolute base, so nothing changes.
+	rss.ResolveURLs()
+	if rss.Channel.Items[0].Link != `1.html` {
+		t.Errorf("Link was resolved without base: '%s'", rss.Channel.Items[0].Link)
+	}
+	rss.Attrs = append(rss.Attrs, xml.Attr{Name: xml.Name{Local: `xml:base`}, Value: `https://foo.com/blog/`})
+	rss.ResolveURLs()
+	ch := rss.Channel
+	item1, item2, item3 := ch.Items[0], ch.Items[1], ch.Items[2]
+	item4, item5 := ch.Items[3], ch.Items[4]
+	for _, tc := range []struct {
+		Got      string
+		Expected string
+	}{
+		{ch.Link, `https://foo.com/news/`},
+		{ch.Docs, `https://foo.com/rss`},
+		{ch.Image.URL, `https://cdn.foo.com/logo.png`},
+		{ch.Image.Link, `https://foo.com/blog/`},
+		{ch.TextInput.Link, `https://foo.com/blog/search`},
+		{ch.AtomLinks[0].Href, `https://foo.com/blog/feed.xml`},
+		{item1.Link, `https://foo.com/blog/1.html`},
+		{item1.Comments, `https://foo.com/blog/1.html#comments`},
+		{item1.Enclosure.URL, `https://foo.com/blog/1.mp3`},
+		{item1.GUID.Value, `https://foo.com/blog/1.html`},
+		{item1.Source.URL, `https://bar.com/rss`},
+		{item2.Link, `https://bar.com/posts/2.html`},
+		{item2.GUID.Value, `2.html`},
+		{item3.GUID.Value, `p/3`},
+		{item4.GUID.Value, `https://foo.com/blog/p/4`},
+		{item5.GUID.Value, `12345`},
+	} {
+		if tc.Got != tc.Expected {
+			t.Errorf("Got '%s'. Expected '%s'", tc.Got, tc.Expected)
+		}
+	}
+
+	// Without xml:base, Channel.Link is the base.
+	rss, _ = Parse([]byte(input))
+	rss.Channel.Link = `https://foo.com/news/`
+	rss.Channel.Items[1].Attrs = []xml.Attr{{Name: xml.Name{Local: `xml:base`}, Value: `../archive/`}}
+	rss.ResolveURLs()
+	expected := []xml.Attr{{Name: xml.Name{Local: `xml:base`}, Value: `https://foo.com/archive/`}}
+	if diff := cmp.Diff(expected, rss.Channel.Items[1].Attrs); diff != "" {
+		t.Errorf("xml:base mismatch (-want +got):\n%s", diff)
+	}
+	if link := rss.Channel.Items[0].Link; link != `https://foo.com/news/1.html` {
+		t.Errorf("Got '%s'. Expected 'https://foo.com/news/1.html'", link)
+	}
+	if link := rss.Channel.Items[1].Link; link != `https://foo.com/archive/2.html` {
+		t.Errorf("Got '%s'. Expected 'https://foo.com/archive/2.html'", link)
+	}
+	item := rss.Channel.Items[1]
+	item.Description = `<a href="3.html">3</a>`
+	if got, expected := item.SanitizedDescription(rss.Channel), `<a href="https://foo.com/archive/3.html">3</a>`; got != expected {
+		t.Errorf("Got '%s'. Expected '%s'", got, expected)
+	}
+}
+
+func TestResolveURLsHTML(t *testing.T) {
+	rss, err := Parse([]byte(`<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/"><channel>
+  <title>Channel title</title>
+  <link>https://foo.com/blog/</link>
+  <description>Channel description</description>
+  <item>
+    <title>Item 1</title>
+    <description><![CDATA[<p class=intro>See <A HREF='1.html' title="a &amp; b">this</A>,<br/><img src=/logo.png alt=""/> and <a href="https://bar.com/">bar</a>.</p><!-- <a href="2.html"> --><script>x = '<a href="3.html">'</script>]]></description>
+    <content:encoded><![CDATA[<blockquote cite="../quote.html">Quote</blockquote>]]></content:encoded>
+  </item>
+</channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rss.ResolveURLs()
+	item := rss.Channel.Items[0]
+	expected := `<p class=intro>See <a href="https://foo.com/blog/1.html" title="a &amp; b">this</A>,<br/><img src="https://foo.com/logo.png" alt="" /> and <a href="https://bar.com/">bar</a>.</p><!-- <a href="2.html"> --><script>x = '<a href="3.html">'</script>`
+	if item.Description != expected {
+		t.Errorf("Got '%s'. Expected '%s'", item.Description, expected)
+	}
+	expected = `<blockquote cite="https://foo.com/quote.html">Quote</blockquote>`
+	if item.Content.Value != expected {
+		t.Errorf("Got '%s'. Expected '%s'", item.Content.Value, expected)
+	}
+}
@@ -1,13 +1,24 @@
 package rss2
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+)
 
-// RSS represents an rss feed. All fields are mandatory.
-// Version must be "2.0" for this library.
+// RSS represents an rss feed. XMLName, Version and Channel are
+// mandatory. Version must be "2.0" for this library. Attrs holds
+// further attributes of the rss element, most notably the declarations
+// of namespaces, that are not known to this package.
+//
+// SourceVersion is set by the parse functions, if the document was
+// upgraded to RSS 2.0. It is "0.90" or "1.0" for RDF documents and
+// "0.91", "0.92", "0.93" or "0.94" for the older versions of RSS.
 type RSS struct {
-	XMLName xml.Name `xml:"rss"`
-	Version string   `xml:"version,attr"`
-	Channel *Channel `xml:"channel"`
+	XMLName       xml.Name   `xml:"rss"`
+	Version       string     `xml:"version,attr"`
+	Attrs         []xml.Attr `xml:",any,attr"`
+	Channel       *Channel   `xml:"channel"`
+	SourceVersion string     `xml:"-"`
 }
 
 // NewRSS creates a new RSS element.
@@ -18,3 +29,92 @@ func NewRSS(ch *Channel) *RSS {
 		Channel: ch,
 	}
 }
+
+// UnmarshalXML unmarshals an RSS element. Elements of the supported
+// extensions are recognized regardless of the prefixes the document
+// uses for their namespaces. Elements of RSS 0.9x, that have been
+// renamed since, are recognized as well.
+func (r *RSS) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	type rss RSS
+	legacy := false
+	for _, attr := range start.Attr {
+		if attr.Name.Local == `version` {
+			legacy = isLegacyVersion(attr.Value)
+		}
+	}
+	return decodePrefixed(decoder, start, (*rss)(r), legacy)
+}
+
+// DeclareNamespace adds the declaration of namespace to Attrs, so that
+// it is declared on the rss element even if the channel does not use
+// it. namespace must be one of the namespaces known to this package,
+// e.g. ITunesNamespace, or registered with RegisterExtension.
+func (r *RSS) DeclareNamespace(namespace string) error {
+	prefix, ok := namespacePrefixes[namespace]
+	if !ok || namespace == xmlNamespace {
+		return fmt.Errorf(`unknown namespace '%s' passed to DeclareNamespace()`, namespace)
+	}
+	attr := xml.Attr{Name: xml.Name{Local: `xmlns:` + prefix}, Value: namespace}
+	if !hasAttr(r.Attrs, attr.Name) {
+		r.Attrs = append(r.Attrs, attr)
+	}
+	return nil
+}
+
+// MarshalXML marshals an RSS element. The namespaces of the extensions
+// used in the channel and those declared with DeclareNamespace are
+// declared on the rss element, followed by the remaining Attrs.
+func (r RSS) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	namespaces := r.namespaces()
+	start.Name = xml.Name{Local: `rss`}
+	start.Attr = append([]xml.Attr{{Name: xml.Name{Local: `version`}, Value: r.Version}},
+		namespaceAttrs(namespaces)...)
+	for _, attr := range r.Attrs {
+		if _, ok := declaredNamespace(attr); !ok && !hasAttr(start.Attr, attr.Name) {
+			start.Attr = append(start.Attr, attr)
+		}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if r.Channel != nil {
+		if err := e.Encode(r.Channel); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// namespaces returns the known namespaces used by the channel of r or
+// declared in r.Attrs.
+func (r *RSS) namespaces() map[string]bool {
+	namespaces := make(map[string]bool)
+	if r.Channel != nil {
+		r.Channel.namespaces(namespaces)
+	}
+	for _, attr := range r.Attrs {
+		if namespace, ok := declaredNamespace(attr); ok {
+			namespaces[namespace] = true
+		}
+	}
+	return namespaces
+}
+
+// declaredNamespace returns the namespace declared by attr, if it is one
+// of the known namespaces with its usual prefix.
+func declaredNamespace(attr xml.Attr) (string, bool) {
+	prefix, ok := namespacePrefixes[attr.Value]
+	if !ok || attr.Value == xmlNamespace || attr.Name != (xml.Name{Local: `xmlns:` + prefix}) {
+		return ``, false
+	}
+	return attr.Value, true
+}
+
+func hasAttr(attrs []xml.Attr, name xml.Name) bool {
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return true
+		}
+	}
+	return false
+}
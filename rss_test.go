@@ -67,8 +67,8 @@ var xmlToRSSTestCases = []TestCase{
 				Link:           `http://liftoff.msfc.nasa.gov/`,
 				Description:    `Liftoff to Space Exploration.`,
 				Language:       `en-us`,
-				PubDate:        &RSSTime{time.Date(2003, 6, 10, 4, 0, 0, 0, time.FixedZone("+0000", 0))},
-				LastBuildDate:  &RSSTime{time.Date(2003, 6, 10, 9, 41, 1, 0, time.FixedZone("+0000", 0))},
+				PubDate:        &RSSTime{Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.FixedZone("+0000", 0))},
+				LastBuildDate:  &RSSTime{Time: time.Date(2003, 6, 10, 9, 41, 1, 0, time.FixedZone("+0000", 0))},
 				Docs:           `http://blogs.law.harvard.edu/tech/rss`,
 				Generator:      `Weblog Editor 2.0`,
 				ManagingEditor: `editor@example.com`,
@@ -79,7 +79,7 @@ var xmlToRSSTestCases = []TestCase{
 						Title:       `Star City`,
 						Link:        `http://liftoff.msfc.nasa.gov/news/2003/news-starcity.asp`,
 						Description: `How do Americans get ready to work with Russians aboard the International Space Station? They take a crash course in culture, language and protocol at Russia's <a href="http://howe.iki.rssi.ru/GCTC/gctc_e.htm">Star City</a>.`,
-						PubDate:     &RSSTime{time.Date(2003, 6, 3, 9, 39, 21, 0, time.FixedZone("+0000", 0))},
+						PubDate:     &RSSTime{Time: time.Date(2003, 6, 3, 9, 39, 21, 0, time.FixedZone("+0000", 0))},
 						GUID: &GUID{
 							XMLName: xml.Name{``, `guid`},
 							Value:   `http://liftoff.msfc.nasa.gov/2003/06/03.html#item573`,
@@ -88,7 +88,7 @@ var xmlToRSSTestCases = []TestCase{
 					{
 						XMLName:     xml.Name{``, `item`},
 						Description: `Sky watchers in Europe, Asia, and parts of Alaska and Canada will experience a <a href="http://science.nasa.gov/headlines/y2003/30may_solareclipse.htm">partial eclipse of the Sun</a> on Saturday, May 31st.`,
-						PubDate:     &RSSTime{time.Date(2003, 5, 30, 11, 6, 42, 0, time.FixedZone("+0000", 0))},
+						PubDate:     &RSSTime{Time: time.Date(2003, 5, 30, 11, 6, 42, 0, time.FixedZone("+0000", 0))},
 						GUID: &GUID{
 							XMLName: xml.Name{``, `guid`},
 							Value:   `http://liftoff.msfc.nasa.gov/2003/05/30.html#item572`,
@@ -99,7 +99,7 @@ var xmlToRSSTestCases = []TestCase{
 						Title:       `The Engine That Does More`,
 						Link:        `http://liftoff.msfc.nasa.gov/news/2003/news-VASIMR.asp`,
 						Description: `Before man travels to Mars, NASA hopes to design new engines that will let us fly through the Solar System more quickly.  The proposed VASIMR engine would do that.`,
-						PubDate:     &RSSTime{time.Date(2003, 5, 27, 8, 37, 32, 0, time.FixedZone("+0000", 0))},
+						PubDate:     &RSSTime{Time: time.Date(2003, 5, 27, 8, 37, 32, 0, time.FixedZone("+0000", 0))},
 						GUID: &GUID{
 							XMLName: xml.Name{``, `guid`},
 							Value:   `http://liftoff.msfc.nasa.gov/2003/05/27.html#item571`,
@@ -110,7 +110,7 @@ var xmlToRSSTestCases = []TestCase{
 						Title:       `Astronauts' Dirty Laundry`,
 						Link:        `http://liftoff.msfc.nasa.gov/news/2003/news-laundry.asp`,
 						Description: `Compared to earlier spacecraft, the International Space Station has many luxuries, but laundry facilities are not one of them.  Instead, astronauts have other options.`,
-						PubDate:     &RSSTime{time.Date(2003, 5, 20, 8, 56, 2, 0, time.FixedZone("+0000", 0))},
+						PubDate:     &RSSTime{Time: time.Date(2003, 5, 20, 8, 56, 2, 0, time.FixedZone("+0000", 0))},
 						GUID: &GUID{
 							XMLName: xml.Name{``, `guid`},
 							Value:   `http://liftoff.msfc.nasa.gov/2003/05/20.html#item570`,
@@ -164,8 +164,8 @@ var xmlToRSSTestCases = []TestCase{
 				Title:         `Channel title with special characters: > " &`,
 				Link:          `foo.com`,
 				Description:   `Channel description`,
-				PubDate:       &RSSTime{time.Date(2003, 6, 10, 4, 0, 0, 0, time.FixedZone(`+0100`, 1*60*60))},
-				LastBuildDate: &RSSTime{time.Date(2003, 6, 10, 9, 41, 0, 0, time.FixedZone(`-0700`, -7*60*60))},
+				PubDate:       &RSSTime{Time: time.Date(2003, 6, 10, 4, 0, 0, 0, time.FixedZone(`+0100`, 1*60*60))},
+				LastBuildDate: &RSSTime{Time: time.Date(2003, 6, 10, 9, 41, 0, 0, time.FixedZone(`-0700`, -7*60*60))},
 				Categories: []*Category{{
 					XMLName: xml.Name{``, `category`},
 					Value:   `Channels domain`,
@@ -199,7 +199,7 @@ var xmlToRSSTestCases = []TestCase{
 					{
 						XMLName: xml.Name{``, `item`},
 						Title:   `Item 1`,
-						PubDate: &RSSTime{time.Date(1993, 6, 3, 9, 39, 21, 0, time.FixedZone("-0700", -7*60*60))},
+						PubDate: &RSSTime{Time: time.Date(1993, 6, 3, 9, 39, 21, 0, time.FixedZone("-0700", -7*60*60))},
 						GUID: &GUID{
 							XMLName:     xml.Name{``, `guid`},
 							Value:       `guid with escapes: > " &`,
@@ -241,7 +241,7 @@ func TestRenderRSS(t *testing.T) {
 	if err != nil {
 		t.Errorf(err.Error())
 	}
-	item1.PubDate = &RSSTime{time.Date(1993, 6, 3, 9, 39, 21, 0,
+	item1.PubDate = &RSSTime{Time: time.Date(1993, 6, 3, 9, 39, 21, 0,
 		time.FixedZone("-0700", -7*60*60))}
 	if item1.GUID, err = NewGUID(`guid with escapes: > " &`); err != nil {
 		t.Errorf(err.Error())
@@ -266,9 +266,9 @@ func TestRenderRSS(t *testing.T) {
 	if err != nil {
 		t.Errorf(err.Error())
 	}
-	channel.PubDate = &RSSTime{time.Date(2003, 6, 10, 4, 0, 0, 0,
+	channel.PubDate = &RSSTime{Time: time.Date(2003, 6, 10, 4, 0, 0, 0,
 		time.FixedZone(`+0100`, 1*60*60))}
-	channel.LastBuildDate = &RSSTime{time.Date(2003, 6, 10, 9, 41, 0, 0,
+	channel.LastBuildDate = &RSSTime{Time: time.Date(2003, 6, 10, 9, 41, 0, 0,
 		time.FixedZone(`-0700`, -7*60*60))}
 	category, err := NewCategory(`Categorie's domain`)
 	if err != nil {
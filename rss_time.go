@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,31 +16,73 @@ var reTimezone = regexp.MustCompile(`^(.* )([A-Z]{1,3})$`)
 
 // RSSTime is a wrapper around time.Time, that makes it possible to
 // define custom MarshalXML() and UnmarshalXML() functions.
+//
+// Raw is only set, if the element could not be parsed and
+// ParseOptions.KeepInvalidDates was used. It then contains the
+// original text of the element and Time is zero.
 type RSSTime struct {
 	Time time.Time
+	Raw  string
 }
 
 // UnmarshalXML unmarshals an RSSTime element.
 func (t *RSSTime) UnmarshalXML(decoder *xml.Decoder,
 	start xml.StartElement) (err error) {
 	var value string
-	if err = decoder.DecodeElement(&value, &start); err == nil {
+	if err = decoder.DecodeElement(&value, &start); err != nil {
+		return
+	}
+	state := stateOf(decoder)
+	if state.opts.LenientTime {
+		*t, err = ParseRSSTimeLenient(value)
+	} else {
 		*t, err = ParseRSSTime(value)
 	}
+	if err != nil && state.opts.KeepInvalidDates {
+		state.warn(&DateError{Element: start.Name.Local, Value: value, Err: err})
+		*t, err = RSSTime{Raw: value}, nil
+	}
 	return
 }
 
-// MarshalXML marshals an RSSTime element.
+// MarshalXML marshals an RSSTime element. If Time is zero and Raw is
+// set, Raw is written unchanged.
 func (t RSSTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.Time.IsZero() && len(t.Raw) > 0 {
+		return e.EncodeElement(t.Raw, start)
+	}
 	return e.EncodeElement(t.Time.Format("02 Jan 2006 15:04:05 -0700"), start)
 }
 
+// DateError is the warning reported for dates that could not be
+// parsed, if ParseOptions.KeepInvalidDates is used.
+type DateError struct {
+	Element string
+	Value   string
+	Err     error
+}
+
+func (e *DateError) Error() string {
+	return fmt.Sprintf("invalid %s '%s': %s", e.Element, e.Value, e.Err.Error())
+}
+
+func (e *DateError) Unwrap() error {
+	return e.Err
+}
+
+func (t *RSSTime) validate(path string) (errs ValidationErrors) {
+	if t.Time.IsZero() && len(t.Raw) > 0 {
+		errs.add(path, `'%s' is not a valid date`, t.Raw)
+	}
+	return
+}
+
 // ParseRSSTime parses a time as specified in RFC822, with the
 // difference, that four digit years are allowed.
 func ParseRSSTime(in string) (out RSSTime, err error) {
 	tmp := []byte(in)
-	// TODO: Research whether multiple consecutive whitspaces are legal
-	//       and reduce them to single ' ', if so.
+	// Multiple consecutive whitespaces are not reduced here, but in
+	// ParseRSSTimeLenient.
 	tmp = removeDayNameIfPresent(tmp)
 	tmp = addSecondsIfMissing(tmp)
 	if tmp, err = convertToFourDigitYearIfNeeded(tmp); err != nil {
@@ -49,7 +92,7 @@ func ParseRSSTime(in string) (out RSSTime, err error) {
 		return
 	}
 	t, err := time.Parse("02 Jan 2006 15:04:05 -0700", string(tmp))
-	return RSSTime{t}, err
+	return RSSTime{Time: t}, err
 }
 
 func removeDayNameIfPresent(in []byte) []byte {
@@ -82,22 +125,23 @@ func convertToFourDigitYearIfNeeded(in []byte) (r []byte, err error) {
 	return
 }
 
-func convertToNumericTimezoneIfNeeded(in []byte) (r []byte, err error) {
-	// The values are the offset from UTC in hours:
-	timezones := map[string]int{
-		"UT": 0, "GMT": 0,
-		"EST": -5, "EDT": -4,
-		"CST": -6, "CDT": -5,
-		"MST": -7, "MDT": -6,
-		"PST": -8, "PDT": -7,
-		"A": 1, "B": 2, "C": 3, "D": 4, "E": 5, "F": 6, "G": 7, "H": 8, "I": 9,
-		"K": 10, "L": 11, "M": 12, "N": -1, "O": -2, "P": -3, "Q": -4, "R": -5,
-		"S": -6, "T": -7, "U": -8, "V": -9, "W": -10, "X": -11, "Y": -12, "Z": 0,
-	}
+// rfc822Timezones contains the timezones defined in RFC822. The values
+// are the offset from UTC in hours.
+var rfc822Timezones = map[string]int{
+	"UT": 0, "GMT": 0,
+	"EST": -5, "EDT": -4,
+	"CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6,
+	"PST": -8, "PDT": -7,
+	"A": 1, "B": 2, "C": 3, "D": 4, "E": 5, "F": 6, "G": 7, "H": 8, "I": 9,
+	"K": 10, "L": 11, "M": 12, "N": -1, "O": -2, "P": -3, "Q": -4, "R": -5,
+	"S": -6, "T": -7, "U": -8, "V": -9, "W": -10, "X": -11, "Y": -12, "Z": 0,
+}
 
+func convertToNumericTimezoneIfNeeded(in []byte) (r []byte, err error) {
 	timezone := reTimezone.ReplaceAll(in, []byte(`$2`))
 	if len(timezone) > 0 && len(timezone) <= 3 {
-		utcOffsetInHours, ok := timezones[string(timezone)]
+		utcOffsetInHours, ok := rfc822Timezones[string(timezone)]
 		if !ok {
 			return nil, fmt.Errorf("invalid timezone '%s'", string(timezone))
 		}
@@ -108,3 +152,199 @@ func convertToNumericTimezoneIfNeeded(in []byte) (r []byte, err error) {
 	}
 	return
 }
+
+// isoLayouts are the ISO 8601 layouts understood by
+// ParseRSSTimeLenient. Times without a timezone are interpreted as UTC.
+var isoLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// lenientTimezones contains the offset from UTC in minutes of the
+// timezone names understood by ParseRSSTimeLenient. Ambiguous names
+// have their North American meaning, as in RFC822, or else their most
+// common one.
+var lenientTimezones = map[string]int{
+	"UT": 0, "UTC": 0, "GMT": 0, "Z": 0, "WET": 0,
+	"BST": 60, "IST": 330, "WEST": 60, "CET": 60, "MET": 60, "MEZ": 60,
+	"CEST": 120, "MEST": 120, "MESZ": 120, "EET": 120, "EEST": 180,
+	"MSK": 180, "PKT": 300, "WIB": 420, "ICT": 420, "HKT": 480,
+	"SGT": 480, "PHT": 480, "AWST": 480, "JST": 540, "KST": 540,
+	"ACST": 570, "ACDT": 630, "AEST": 600, "AEDT": 660, "NZST": 720,
+	"NZDT": 780, "NST": -210, "NDT": -150, "AST": -240, "ADT": -180,
+	"BRT": -180, "ART": -180, "EST": -300, "EDT": -240, "CST": -360,
+	"CDT": -300, "MST": -420, "MDT": -360, "PST": -480, "PDT": -420,
+	"AKST": -540, "AKDT": -480, "HST": -600,
+}
+
+// ParseRSSTimeLenient parses times like ParseRSSTime, but additionally
+// accepts many formats found in real-world feeds: RFC3339 and other
+// ISO 8601 formats, single-digit days and hours, full day and month
+// names, superfluous whitespace and commas, missing timezones, which
+// are interpreted as UTC, and a wide range of timezone names.
+func ParseRSSTimeLenient(in string) (RSSTime, error) {
+	if t, err := ParseRSSTime(in); err == nil {
+		return t, nil
+	}
+	in = strings.Join(strings.Fields(in), " ")
+	for _, layout := range isoLayouts {
+		if t, err := time.Parse(layout, in); err == nil {
+			return RSSTime{Time: t}, nil
+		}
+	}
+	normalized, err := normalizeRFC822(in)
+	if err != nil {
+		return RSSTime{}, fmt.Errorf("invalid time '%s': %s", in, err.Error())
+	}
+	t, err := time.Parse("02 Jan 2006 15:04:05 -0700", normalized)
+	return RSSTime{Time: t}, err
+}
+
+// normalizeRFC822 converts a loosely RFC822 formatted time to the
+// format "02 Jan 2006 15:04:05 -0700".
+func normalizeRFC822(in string) (string, error) {
+	fields := strings.Fields(strings.ReplaceAll(in, ",", " "))
+	if len(fields) > 0 && parseDayName(fields[0]) {
+		fields = fields[1:]
+	}
+	if len(fields) > 1 {
+		// Allow the month before the day, as in "May 1 2023".
+		if _, err := parseMonth(fields[0]); err == nil {
+			fields[0], fields[1] = fields[1], fields[0]
+		}
+	}
+	if len(fields) == 4 {
+		fields = append(fields, "UTC")
+	} else if len(fields) == 6 && strings.HasPrefix(fields[5], "(") {
+		// Ignore comments like in "+0200 (CEST)".
+		fields = fields[:5]
+	}
+	if len(fields) != 5 {
+		return "", fmt.Errorf("unexpected number of fields")
+	}
+	day, err := strconv.Atoi(fields[0])
+	if err != nil || day < 1 || day > 31 {
+		return "", fmt.Errorf("invalid day '%s'", fields[0])
+	}
+	month, err := parseMonth(fields[1])
+	if err != nil {
+		return "", err
+	}
+	year, err := strconv.Atoi(fields[2])
+	if err != nil || len(fields[2]) != 2 && len(fields[2]) != 4 {
+		return "", fmt.Errorf("invalid year '%s'", fields[2])
+	} else if len(fields[2]) == 2 && year >= 70 {
+		year += 1900
+	} else if len(fields[2]) == 2 {
+		year += 2000
+	}
+	clock, err := parseClock(fields[3])
+	if err != nil {
+		return "", err
+	}
+	zone, err := parseTimezone(fields[4])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%02d %s %d %s %s", day, month, year, clock, zone), nil
+}
+
+func parseDayName(in string) bool {
+	in = strings.ToLower(in)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if len(in) >= 3 && strings.HasPrefix(name, in) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMonth returns the three letter abbreviation of a full or
+// abbreviated month name.
+func parseMonth(in string) (string, error) {
+	lower := strings.ToLower(strings.TrimSuffix(in, "."))
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		if len(lower) >= 3 && strings.HasPrefix(name, lower) ||
+			lower == "sept" && month == time.September {
+			return month.String()[:3], nil
+		}
+	}
+	return "", fmt.Errorf("invalid month '%s'", in)
+}
+
+// parseClock converts times like "9:05" to "09:05:00".
+func parseClock(in string) (string, error) {
+	parts := strings.Split(in, ":")
+	if len(parts) == 2 {
+		parts = append(parts, "00")
+	}
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid time of day '%s'", in)
+	}
+	var values [3]int
+	for i, part := range parts {
+		if i == 2 {
+			// Ignore fractional seconds.
+			part = strings.SplitN(part, ".", 2)[0]
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil || len(part) > 2 {
+			return "", fmt.Errorf("invalid time of day '%s'", in)
+		}
+		values[i] = value
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", values[0], values[1], values[2]), nil
+}
+
+// parseTimezone converts numeric or named timezones to the format
+// "-0700".
+func parseTimezone(in string) (string, error) {
+	if offset, ok := lenientTimezones[strings.ToUpper(in)]; ok {
+		return formatOffset(offset), nil
+	} else if offset, ok := rfc822Timezones[strings.ToUpper(in)]; ok {
+		return formatOffset(offset * 60), nil
+	}
+	for _, prefix := range []string{"GMT", "UTC", "UT"} {
+		// Allow timezones like "GMT+0200".
+		if len(in) > len(prefix) && strings.EqualFold(in[:len(prefix)], prefix) {
+			in = in[len(prefix):]
+			break
+		}
+	}
+	if len(in) < 2 || in[0] != '+' && in[0] != '-' {
+		return "", fmt.Errorf("invalid timezone '%s'", in)
+	}
+	digits := strings.ReplaceAll(in[1:], ":", "")
+	if len(digits) <= 2 {
+		digits += "00"
+	}
+	if len(digits) == 3 {
+		digits = "0" + digits
+	}
+	hours, err1 := strconv.Atoi(digits[:2])
+	minutes, err2 := strconv.Atoi(digits[2:])
+	if len(digits) != 4 || err1 != nil || err2 != nil {
+		return "", fmt.Errorf("invalid timezone '%s'", in)
+	}
+	offset := hours*60 + minutes
+	if in[0] == '-' {
+		offset = -offset
+	}
+	return formatOffset(offset), nil
+}
+
+func formatOffset(minutes int) string {
+	sign := '+'
+	if minutes < 0 {
+		sign, minutes = '-', -minutes
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, minutes/60, minutes%60)
+}
@@ -7,42 +7,42 @@ import (
 
 func TestParseRSSTime(t *testing.T) {
 	testCases := map[string]RSSTime{
-		"Sat, 07 Sep 2002 00:08:01 UT":  {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"Mon, 09 Sep 2002 00:08:01 UT":  {time.Date(2002, 9, 9, 0, 8, 1, 0, time.UTC)},
-		"Sat, 07 Sep 2002 00:08 UT":     {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
-		"Sat, 07 Sep 2002 00:08:01 GMT": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"Sat, 07 Sep 02 00:08:01 GMT":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"Sat, 07 Sep 02 00:08 GMT":      {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
-		"07 Sep 02 00:08 GMT":           {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
-		"07 Sep 97 00:08 GMT":           {time.Date(1997, 9, 7, 0, 8, 0, 0, time.UTC)},
-		"07 Sep 2002 00:08:01 UT":       {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 00:08 UT":          {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
-		"07 Sep 2002 00:08:01 GMT":      {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Aug 2001 05:08:01 GMT":      {time.Date(2001, 8, 7, 5, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 00:08:01 UT":  {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Mon, 09 Sep 2002 00:08:01 UT":  {Time: time.Date(2002, 9, 9, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 00:08 UT":     {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"Sat, 07 Sep 2002 00:08:01 GMT": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 02 00:08:01 GMT":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 02 00:08 GMT":      {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"07 Sep 02 00:08 GMT":           {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"07 Sep 97 00:08 GMT":           {Time: time.Date(1997, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"07 Sep 2002 00:08:01 UT":       {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 00:08 UT":          {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"07 Sep 2002 00:08:01 GMT":      {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Aug 2001 05:08:01 GMT":      {Time: time.Date(2001, 8, 7, 5, 8, 1, 0, time.UTC)},
 		// Military time zones:
-		"07 Sep 2002 00:08:01 Z":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 01:08:01 A":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 02:08:01 B":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 12:08:01 M":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 23:08:01 N":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 12:08:01 Y":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"Fri, 06 Sep 2002 12:08 Y": {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"07 Sep 2002 00:08:01 Z":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 01:08:01 A":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 02:08:01 B":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 12:08:01 M":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 23:08:01 N":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 12:08:01 Y":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Fri, 06 Sep 2002 12:08 Y": {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
 		// 3-character timezone indicators for North America:
-		"06 Sep 2002 19:08:01 EST": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 20:08:01 EDT": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 18:08:01 CST": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 19:08:01 CDT": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 17:08:01 MST": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 18:08:01 MDT": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 16:08:01 PST": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 17:08:01 PDT": {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"06 Sep 2002 17:08 PDT":    {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"06 Sep 2002 19:08:01 EST": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 20:08:01 EDT": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 18:08:01 CST": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 19:08:01 CDT": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 17:08:01 MST": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 18:08:01 MDT": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 16:08:01 PST": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 17:08:01 PDT": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"06 Sep 2002 17:08 PDT":    {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
 		// Explicit indication of the offset from UTC:
-		"06 Sep 2002 17:08:01 -0700":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 11:08:01 +1100":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 00:08:01 -0000":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"07 Sep 2002 00:13:01 +0005":   {time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
-		"Fri, 06 Sep 2002 17:08 -0700": {time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"06 Sep 2002 17:08:01 -0700":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 11:08:01 +1100":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 00:08:01 -0000":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 2002 00:13:01 +0005":   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Fri, 06 Sep 2002 17:08 -0700": {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
 	}
 	for in, expected := range testCases {
 		if out, err := ParseRSSTime(in); err != nil {
@@ -54,6 +54,43 @@ func TestParseRSSTime(t *testing.T) {
 	}
 }
 
+func TestParseRSSTimeLenient(t *testing.T) {
+	testCases := map[string]RSSTime{
+		"Sat, 07 Sep 2002 00:08:01 UT":           {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"2002-09-07T00:08:01Z":                   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"2002-09-07T02:08:01+02:00":              {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"2002-09-07T02:08:01.123+02:00":          {Time: time.Date(2002, 9, 7, 0, 8, 1, 123000000, time.UTC)},
+		"2002-09-07T02:08:01+0200":               {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"2002-09-07 00:08:01":                    {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"2002-09-07":                             {Time: time.Date(2002, 9, 7, 0, 0, 0, 0, time.UTC)},
+		"Sat, 7 Sep 2002 00:08:01 +0000":         {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat,  07 Sep  2002 00:08:01   GMT":      {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Saturday, 07 September 2002 0:08 GMT":   {Time: time.Date(2002, 9, 7, 0, 8, 0, 0, time.UTC)},
+		"Sat, 07 Sept 2002 02:08:01 CEST":        {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 09:38:01 ACST":         {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 sep 2002 00:08:01 utc":          {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 00:08:01":              {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 02:08:01 +02:00":       {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 02:08:01 GMT+0200":     {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"Sat, 07 Sep 2002 02:08:01 +0200 (CEST)": {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"September 7, 2002 00:08:01 GMT":         {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+		"07 Sep 02 01:08:01 A":                   {Time: time.Date(2002, 9, 7, 0, 8, 1, 0, time.UTC)},
+	}
+	for in, expected := range testCases {
+		if out, err := ParseRSSTimeLenient(in); err != nil {
+			t.Errorf("Error parsing '%s': %s", in, err.Error())
+		} else if !out.Time.Equal(expected.Time) {
+			t.Errorf("Parsing '%s' yielded '%s'. Expected '%s'", in, out.Time.String(),
+				expected.Time.String())
+		}
+	}
+	for _, in := range []string{"", "yesterday", "Sat, 07 Sep 2002 00:08:01 XYZ", "32 Sep 2002 00:08 GMT"} {
+		if _, err := ParseRSSTimeLenient(in); err == nil {
+			t.Errorf("Expected error parsing '%s'", in)
+		}
+	}
+}
+
 func BenchmarkParseRSSTimeEasy(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ParseRSSTime("06 Sep 2002 17:08:00 +0000")
@@ -0,0 +1,375 @@
+package rss2
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// allowedElements maps the HTML elements kept by SanitizeHTML to the
+// attributes kept for them, in addition to globalAttrs.
+var allowedElements = map[string][]string{
+	`a`: {`href`}, `abbr`: nil, `b`: nil, `blockquote`: {`cite`},
+	`br`: nil, `caption`: nil, `cite`: nil, `code`: nil, `dd`: nil,
+	`del`: {`cite`, `datetime`}, `dfn`: nil, `div`: nil, `dl`: nil,
+	`dt`: nil, `em`: nil, `figcaption`: nil, `figure`: nil, `h1`: nil,
+	`h2`: nil, `h3`: nil, `h4`: nil, `h5`: nil, `h6`: nil, `hr`: nil,
+	`i`: nil, `img`: {`src`, `alt`, `width`, `height`},
+	`ins`: {`cite`, `datetime`}, `kbd`: nil, `li`: nil, `mark`: nil,
+	`ol`: {`start`, `reversed`}, `p`: nil, `pre`: nil, `q`: {`cite`},
+	`s`: nil, `samp`: nil, `small`: nil, `span`: nil, `strong`: nil,
+	`sub`: nil, `sup`: nil, `table`: nil, `tbody`: nil,
+	`td`: {`colspan`, `rowspan`}, `tfoot`: nil,
+	`th`: {`colspan`, `rowspan`, `scope`}, `thead`: nil, `time`: {`datetime`},
+	`tr`: nil, `u`: nil, `ul`: nil,
+}
+
+var globalAttrs = []string{`title`, `lang`, `dir`}
+
+// urlAttrs maps the attributes holding URLs to the schemes allowed for
+// them.
+var urlAttrs = map[string][]string{
+	`href`: {`http`, `https`, `mailto`},
+	`src`:  {`http`, `https`},
+	`cite`: {`http`, `https`},
+}
+
+// voidElements are HTML elements without content and end tag.
+var voidElements = map[string]bool{
+	`area`: true, `base`: true, `br`: true, `col`: true, `embed`: true,
+	`hr`: true, `img`: true, `input`: true, `link`: true, `meta`: true,
+	`source`: true, `track`: true, `wbr`: true,
+}
+
+// rawTextElements are HTML elements, whose content is not HTML. Their
+// content is dropped entirely.
+var rawTextElements = map[string]bool{
+	`script`: true, `style`: true, `textarea`: true, `title`: true,
+	`xmp`: true, `iframe`: true, `noembed`: true, `noframes`: true,
+	`noscript`: true, `plaintext`: true,
+}
+
+// blockElements are HTML elements, that start a new line in plain
+// text.
+var blockElements = map[string]bool{
+	`address`: true, `article`: true, `aside`: true, `blockquote`: true,
+	`br`: true, `dd`: true, `div`: true, `dl`: true, `dt`: true,
+	`figcaption`: true, `figure`: true, `footer`: true, `h1`: true,
+	`h2`: true, `h3`: true, `h4`: true, `h5`: true, `h6`: true,
+	`header`: true, `hr`: true, `li`: true, `ol`: true, `p`: true,
+	`pre`: true, `section`: true, `table`: true, `tr`: true, `ul`: true,
+}
+
+// SanitizeHTML reduces the HTML fragment s to a subset, that is safe to
+// embed into web pages. Only common formatting elements, links and
+// images are kept, with few of their attributes. Scripts, styles,
+// event handlers and URLs of schemes other than http, https and mailto
+// are removed, while the text of other elements is kept. Elements are
+// closed properly.
+//
+// Relative URLs are resolved against base, which may be nil to keep
+// them relative.
+func SanitizeHTML(s string, base *url.URL) string {
+	var b strings.Builder
+	var open []string
+	for _, token := range tokenizeHTML(s) {
+		switch token.typ {
+		case htmlText:
+			b.WriteString(html.EscapeString(token.data))
+		case htmlStartTag:
+			attrs, ok := allowedElements[token.data]
+			if !ok {
+				continue
+			}
+			b.WriteString(`<` + token.data)
+			written := make(map[string]bool)
+			for _, attr := range token.attrs {
+				if written[attr.name] || !containsString(attrs, attr.name) && !containsString(globalAttrs, attr.name) {
+					continue
+				}
+				value := attr.value
+				if schemes, ok := urlAttrs[attr.name]; ok {
+					if value, ok = sanitizeURL(value, base, schemes); !ok {
+						continue
+					}
+				}
+				written[attr.name] = true
+				b.WriteString(` ` + attr.name + `="` + html.EscapeString(value) + `"`)
+			}
+			b.WriteString(`>`)
+			if !voidElements[token.data] {
+				open = append(open, token.data)
+			}
+		case htmlEndTag:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.data {
+					closeElements(&b, open[i:])
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+	closeElements(&b, open)
+	return b.String()
+}
+
+// closeElements writes the end tags of open in reverse order.
+func closeElements(b *strings.Builder, open []string) {
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString(`</` + open[i] + `>`)
+	}
+}
+
+// sanitizeURL resolves s against base and reports whether the result
+// is relative or of one of the given schemes.
+func sanitizeURL(s string, base *url.URL, schemes []string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return ``, false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if len(u.Scheme) > 0 && !containsString(schemes, u.Scheme) {
+		return ``, false
+	}
+	return u.String(), true
+}
+
+// HTMLText returns the text of the HTML fragment s, e.g. for
+// notifications. Whitespace is collapsed, while block elements like
+// paragraphs and line breaks start new lines. Empty lines are dropped.
+func HTMLText(s string) string {
+	var b strings.Builder
+	for _, token := range tokenizeHTML(s) {
+		switch token.typ {
+		case htmlText:
+			b.WriteString(strings.ReplaceAll(token.data, "\n", ` `))
+		case htmlStartTag, htmlEndTag:
+			if blockElements[token.data] {
+				b.WriteByte('\n')
+			}
+		}
+	}
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), ` `); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SanitizedDescription returns Description reduced to a safe subset of
+// HTML; see SanitizeHTML. Relative URLs are resolved against the
+// xml:base attribute of it, Link or the base of ch, in this order. The
+// base of ch is its xml:base attribute or its link. ch may be nil.
+func (it *Item) SanitizedDescription(ch *Channel) string {
+	return SanitizeHTML(it.Description, it.baseURL(ch))
+}
+
+// TextDescription returns the text of Description; see HTMLText.
+func (it *Item) TextDescription() string {
+	return HTMLText(it.Description)
+}
+
+// baseURL returns the absolute URL, that relative URLs in the content of
+// it are relative to, or nil if it is unknown.
+func (it *Item) baseURL(ch *Channel) *url.URL {
+	var base *url.URL
+	if ch != nil {
+		base = ch.baseURL()
+	}
+	if itemBase := xmlBase(it.Attrs, base); itemBase != nil {
+		return itemBase
+	}
+	if len(strings.TrimSpace(it.Link)) == 0 {
+		return base
+	}
+	if link := absoluteURL(resolveURL(it.Link, base)); link != nil {
+		return link
+	}
+	return base
+}
+
+type htmlTokenType int
+
+const (
+	htmlText htmlTokenType = iota
+	htmlStartTag
+	htmlEndTag
+)
+
+// htmlToken is a token of an HTML fragment. data is the unescaped text
+// or the lower case name of the element.
+type htmlToken struct {
+	typ   htmlTokenType
+	data  string
+	attrs []htmlAttr
+}
+
+type htmlAttr struct {
+	name  string
+	value string
+}
+
+// tokenizeHTML splits the HTML fragment s into text and tags. Comments,
+// doctypes, processing instructions and the content of rawTextElements
+// are dropped. The tokenizer is forgiving like browsers are: a "<",
+// that does not start a tag, is text and unterminated tags are
+// dropped.
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, htmlToken{typ: htmlText, data: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			text.WriteString(s)
+			break
+		}
+		text.WriteString(s[:i])
+		s = s[i:]
+		switch {
+		case strings.HasPrefix(s, `<!--`):
+			end := strings.Index(s[4:], `-->`)
+			if end < 0 {
+				s = ``
+			} else {
+				s = s[4+end+3:]
+			}
+		case strings.HasPrefix(s, `<![CDATA[`):
+			end := strings.Index(s, `]]>`)
+			if end < 0 {
+				end = len(s)
+			}
+			// The content of CDATA sections must not be unescaped.
+			flushText()
+			tokens = append(tokens, htmlToken{typ: htmlText, data: s[len(`<![CDATA[`):end]})
+			s = s[minInt(end+3, len(s)):]
+		case strings.HasPrefix(s, `<!`), strings.HasPrefix(s, `<?`):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				s = ``
+			} else {
+				s = s[end+1:]
+			}
+		case len(s) > 1 && isASCIILetter(s[1]), len(s) > 2 && s[1] == '/' && isASCIILetter(s[2]):
+			flushText()
+			token, n := parseHTMLTag(s)
+			s = s[n:]
+			if n == 0 {
+				// The tag is unterminated.
+				s = ``
+				break
+			}
+			tokens = append(tokens, token)
+			if token.typ == htmlStartTag && rawTextElements[token.data] {
+				s = s[indexEndTag(s, token.data):]
+			}
+		default:
+			text.WriteByte('<')
+			s = s[1:]
+		}
+	}
+	flushText()
+	return tokens
+}
+
+// parseHTMLTag parses the tag at the beginning of s and returns it
+// together with its length. The returned length is 0, if the tag is not
+// terminated.
+func parseHTMLTag(s string) (htmlToken, int) {
+	token := htmlToken{typ: htmlStartTag}
+	i := 1
+	if s[i] == '/' {
+		token.typ = htmlEndTag
+		i++
+	}
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	token.data = strings.ToLower(s[start:i])
+	for i < len(s) {
+		if isHTMLSpace(s[i]) || s[i] == '/' {
+			i++
+			continue
+		} else if s[i] == '>' {
+			return token, i + 1
+		}
+		start = i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '=' {
+			i++
+		}
+		attr := htmlAttr{name: strings.ToLower(s[start:i])}
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					return token, 0
+				}
+				attr.value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start = i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				attr.value = s[start:i]
+			}
+		}
+		attr.value = html.UnescapeString(attr.value)
+		token.attrs = append(token.attrs, attr)
+	}
+	return token, 0
+}
+
+// indexEndTag returns the index of the first end tag of the element
+// with the given name in s or len(s), if there is none.
+func indexEndTag(s, name string) int {
+	for i := 0; i+len(name)+2 <= len(s); i++ {
+		if s[i] == '<' && s[i+1] == '/' && strings.EqualFold(s[i+2:i+2+len(name)], name) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,73 @@
+package rss2
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	base, _ := url.Parse(`https://foo.com/news/1`)
+	for _, tc := range []struct {
+		Input    string
+		Expected string
+	}{
+		{`<p>Hello <b>World</b></p>`, `<p>Hello <b>World</b></p>`},
+		{`<P CLASS="x" style="color: red" onclick="alert(1)">Hi</P>`, `<p>Hi</p>`},
+		{`<script>alert("<b>")</script><style>p {}</style>Text`, `Text`},
+		{`<SCRIPT src=x></Script >Text`, `Text`},
+		{`<a href="javascript:alert(1)">Link</a>`, `<a>Link</a>`},
+		{`<a href="JaVaScRiPt:alert(1)">Link</a>`, `<a>Link</a>`},
+		{`<a href="java&#x09;script:alert(1)">Link</a>`, `<a>Link</a>`},
+		{`<a href="../2" title='Next "one"'>Next</a>`, `<a href="https://foo.com/2" title="Next &#34;one&#34;">Next</a>`},
+		{`<a href=mailto:jane@foo.com>Jane</a>`, `<a href="mailto:jane@foo.com">Jane</a>`},
+		{`<img src="/logo.png" alt=Logo onerror="alert(1)"><br/>`, `<img src="https://foo.com/logo.png" alt="Logo"><br>`},
+		{`<img src="data:image/png;base64,AAAA">`, `<img>`},
+		{`<div><p>Unclosed <i>tags`, `<div><p>Unclosed <i>tags</i></p></div>`},
+		{`<b><i>Misnested</b></i>`, `<b><i>Misnested</i></b>`},
+		{`</p>Stray end tag`, `Stray end tag`},
+		{`<form><input value="x">Kept text</form>`, `Kept text`},
+		{`<iframe src="https://evil.com/"><p>Fallback</p></iframe>After`, `After`},
+		{`a < b &amp;&amp; c > d &eacute;`, `a &lt; b &amp;&amp; c &gt; d é`},
+		{`<!-- <script>alert(1)</script> -->Text<!DOCTYPE html>`, `Text`},
+		{`<![CDATA[<b>&amp;</b>]]>`, `&lt;b&gt;&amp;amp;&lt;/b&gt;`},
+		{`Unterminated <a href="x`, `Unterminated `},
+		{`<svg><script>alert(1)</script></svg>Text`, `Text`},
+	} {
+		if got := SanitizeHTML(tc.Input, base); got != tc.Expected {
+			t.Errorf("Sanitizing '%s' yielded '%s'. Expected '%s'", tc.Input, got, tc.Expected)
+		}
+	}
+	if got := SanitizeHTML(`<a href="/1">1</a>`, nil); got != `<a href="/1">1</a>` {
+		t.Errorf("Relative link was not kept, got '%s'", got)
+	}
+}
+
+func TestHTMLText(t *testing.T) {
+	input := `<h1>Title</h1><p>First   paragraph
+with <b>bold</b>&nbsp;text.</p><script>alert(1)</script><ul><li>One</li><li>Two</li></ul>Line<br>break &lt;3`
+	expected := "Title\nFirst paragraph with bold text.\nOne\nTwo\nLine\nbreak <3"
+	if diff := cmp.Diff(expected, HTMLText(input)); diff != "" {
+		t.Errorf("Text mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSanitizedDescription(t *testing.T) {
+	channel, _ := NewChannel(`Channel title`, `https://foo.com/`, `Channel description`)
+	item, _ := NewItem(``, `<a href="more">More</a> <img src="img.png" onload="x()">`)
+	if got, expected := item.SanitizedDescription(channel), `<a href="https://foo.com/more">More</a> <img src="https://foo.com/img.png">`; got != expected {
+		t.Errorf("Got '%s'. Expected '%s'", got, expected)
+	}
+	item.Link = `/posts/1/`
+	if got, expected := item.SanitizedDescription(channel), `<a href="https://foo.com/posts/1/more">More</a> <img src="https://foo.com/posts/1/img.png">`; got != expected {
+		t.Errorf("Got '%s'. Expected '%s'", got, expected)
+	}
+	item.Link = `https://bar.com/post`
+	if got, expected := item.SanitizedDescription(nil), `<a href="https://bar.com/more">More</a> <img src="https://bar.com/img.png">`; got != expected {
+		t.Errorf("Got '%s'. Expected '%s'", got, expected)
+	}
+	if got := item.TextDescription(); got != `More` {
+		t.Errorf("Got '%s'. Expected 'More'", got)
+	}
+}
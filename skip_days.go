@@ -31,3 +31,21 @@ func NewSkipDays(days []time.Weekday) *SkipDays {
 		Days:    daysString,
 	}
 }
+
+func (s *SkipDays) validate(path string) (errs ValidationErrors) {
+	for i, day := range s.Days {
+		if !isDayName(day) {
+			errs.add(indexPath(path, `day`, i), `'%s' is not the name of a weekday`, day)
+		}
+	}
+	return
+}
+
+func isDayName(day string) bool {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if day == weekday.String() {
+			return true
+		}
+	}
+	return false
+}
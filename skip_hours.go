@@ -25,3 +25,12 @@ func NewSkipHours(hours []int) (*SkipHours, error) {
 		Hours:   hours,
 	}, nil
 }
+
+func (s *SkipHours) validate(path string) (errs ValidationErrors) {
+	for i, hour := range s.Hours {
+		if hour < 0 || hour > 23 {
+			errs.add(indexPath(path, `hour`, i), `%d not between 0 and 23`, hour)
+		}
+	}
+	return
+}
@@ -23,3 +23,8 @@ func NewSource(value, url string) (*Source, error) {
 		URL:     url,
 	}, nil
 }
+
+func (s *Source) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`@url`, s.URL)
+	return
+}
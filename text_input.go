@@ -29,3 +29,11 @@ func NewTextInput(title, description, name, link string) (*TextInput, error) {
 		Link:        link,
 	}, nil
 }
+
+func (t *TextInput) validate(path string) (errs ValidationErrors) {
+	errs.requireNonEmpty(path+`/title`, t.Title)
+	errs.requireNonEmpty(path+`/description`, t.Description)
+	errs.requireNonEmpty(path+`/name`, t.Name)
+	errs.requireNonEmpty(path+`/link`, t.Link)
+	return
+}
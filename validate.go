@@ -0,0 +1,80 @@
+package rss2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a single violation of the RSS 2.0
+// specification. Path points to the offending element or attribute,
+// relative to the rss element, e.g. "channel/item[3]/enclosure@type".
+// Indices are those of the corresponding Go slices and thus start at 0.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf(`%s: %s`, e.Path, e.Message)
+}
+
+// ValidationErrors is the list of violations returned by Validate.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, `; `)
+}
+
+// Validate checks r and all its descendants for violations of the
+// RSS 2.0 specification. This is useful for feeds that have been
+// created without the New<element>() functions or have been parsed.
+// If violations are found, they are returned as ValidationErrors.
+func (r *RSS) Validate() error {
+	var errs ValidationErrors
+	if r.Version != `2.0` {
+		errs.add(`@version`, `must be "2.0"`)
+	}
+	if r.Channel == nil {
+		errs.add(`channel`, `must be present`)
+	} else {
+		errs = append(errs, r.Channel.validate(`channel`)...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (e *ValidationErrors) add(path, format string, a ...interface{}) {
+	*e = append(*e, &ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+func (e *ValidationErrors) requireNonEmpty(path, value string) {
+	if len(value) == 0 {
+		e.add(path, `must not be empty`)
+	}
+}
+
+func (e *ValidationErrors) requireOneOf(path, value string, valid ...string) {
+	for _, v := range valid {
+		if value == v {
+			return
+		}
+	}
+	switch len(valid) {
+	case 0:
+		e.add(path, `'%s' is not allowed`, value)
+	case 1:
+		e.add(path, `'%s' is not '%s'`, value, valid[0])
+	default:
+		e.add(path, `'%s' is not one of '%s'`, value, strings.Join(valid, `', '`))
+	}
+}
+
+func indexPath(path, element string, i int) string {
+	return fmt.Sprintf(`%s/%s[%d]`, path, element, i)
+}
@@ -0,0 +1,65 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range xmlToRSSTestCases {
+		if err := tc.Expected.Validate(); err != nil {
+			t.Errorf("Unexpected validation error: %s", err.Error())
+		}
+	}
+
+	rss := RSS{
+		Version: `2.0`,
+		Channel: &Channel{
+			Title:      `Channel title`,
+			Link:       `foo.com`,
+			Categories: []*Category{{Value: `ok`}, {}},
+			Cloud:      &Cloud{Domain: `rpc.sys.com`, Path: `/RPC2`, RegisterProcedure: `pingMe`, Protocol: `soap`},
+			Image:      &Image{URL: `url`, Title: `title`, Link: `link`, Width: 145, Height: 400},
+			TextInput:  &TextInput{Title: `title`, Description: `description`, Link: `link`},
+			SkipHours:  &SkipHours{Hours: []int{0, 24}},
+			SkipDays:   &SkipDays{Days: []string{`Sunday`, `Funday`}},
+			Items: []*Item{
+				{Title: `Item 0`},
+				{Link: `foo.com/1`, Enclosure: &Enclosure{URL: `foo.com/1.mp3`}},
+				{Title: `Item 2`, GUID: &GUID{}, Source: &Source{Value: `source`}},
+			},
+		},
+	}
+	expected := ValidationErrors{
+		{`channel/description`, `must not be empty`},
+		{`channel/category[1]`, `must not be empty`},
+		{`channel/cloud@port`, `0 is not a valid port`},
+		{`channel/image/width`, `145 not between 0 and 144`},
+		{`channel/textInput/name`, `must not be empty`},
+		{`channel/skipHours/hour[1]`, `24 not between 0 and 23`},
+		{`channel/skipDays/day[1]`, `'Funday' is not the name of a weekday`},
+		{`channel/item[1]`, `title or description must be present`},
+		{`channel/item[1]/enclosure@type`, `must not be empty`},
+		{`channel/item[2]/guid`, `must not be empty`},
+		{`channel/item[2]/source@url`, `must not be empty`},
+	}
+	var errs ValidationErrors
+	if err := rss.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got '%v'", err)
+	}
+	if diff := cmp.Diff(expected, errs); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+
+	rss = RSS{XMLName: xml.Name{Local: `rss`}}
+	expected = ValidationErrors{
+		{`@version`, `must be "2.0"`},
+		{`channel`, `must be present`},
+	}
+	if diff := cmp.Diff(expected, rss.Validate()); diff != "" {
+		t.Errorf("Validation mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+package rss2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// w3cLayouts are the layouts of the W3C date and time format.
+var w3cLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// W3CTime is a wrapper around time.Time for elements that use the W3C
+// date and time format, a profile of ISO 8601, instead of RFC822. An
+// example is "2003-06-10T04:00:00Z".
+//
+// Raw is only set, if the element could not be parsed and
+// ParseOptions.KeepInvalidDates was used. It then contains the
+// original text of the element and Time is zero.
+type W3CTime struct {
+	Time time.Time
+	Raw  string
+}
+
+// UnmarshalXML unmarshals a W3CTime element. If
+// ParseOptions.LenientTime is used, the formats understood by
+// ParseRSSTimeLenient are accepted as well.
+func (t *W3CTime) UnmarshalXML(decoder *xml.Decoder,
+	start xml.StartElement) (err error) {
+	var value string
+	if err = decoder.DecodeElement(&value, &start); err != nil {
+		return
+	}
+	*t, err = stateOf(decoder).parseW3CTime(start.Name.Local, value)
+	return
+}
+
+// parseW3CTime parses the value of the given element like ParseW3CTime,
+// but respects the options of s.
+func (s *decodeState) parseW3CTime(element, value string) (t W3CTime, err error) {
+	t, err = ParseW3CTime(value)
+	if err != nil && s.opts.LenientTime {
+		var rssTime RSSTime
+		if rssTime, err = ParseRSSTimeLenient(value); err == nil {
+			t = W3CTime{Time: rssTime.Time}
+		}
+	}
+	if err != nil && s.opts.KeepInvalidDates {
+		s.warn(&DateError{Element: element, Value: value, Err: err})
+		t, err = W3CTime{Raw: value}, nil
+	}
+	return
+}
+
+// MarshalXML marshals a W3CTime element. If Time is zero and Raw is
+// set, Raw is written unchanged.
+func (t W3CTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.Time.IsZero() && len(t.Raw) > 0 {
+		return e.EncodeElement(t.Raw, start)
+	}
+	return e.EncodeElement(t.Time.Format(time.RFC3339), start)
+}
+
+// ParseW3CTime parses a time in the W3C date and time format. Dates
+// with reduced precision, like "2003-06" are allowed.
+func ParseW3CTime(in string) (W3CTime, error) {
+	in = strings.TrimSpace(in)
+	for _, layout := range w3cLayouts {
+		if t, err := time.Parse(layout, in); err == nil {
+			return W3CTime{Time: t}, nil
+		}
+	}
+	return W3CTime{}, fmt.Errorf("invalid time '%s'", in)
+}
+
+func (t *W3CTime) validate(path string) (errs ValidationErrors) {
+	if t.Time.IsZero() && len(t.Raw) > 0 {
+		errs.add(path, `'%s' is not a valid date`, t.Raw)
+	}
+	return
+}